@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/timHau/graph/pq"
+)
+
+// ErrNoPath is returned by point to point searches when the goal can not be reached from the start
+var ErrNoPath = errors.New("goal is not reachable from start")
+
+// debug enables expensive sanity checks, like the consistency check of A* heuristics.
+// It is switched on by building with the debug tag: go test -tags debug .
+var debug = false
+
+// A* search for a single shortest path
+//
+// Like Dijkstra, but the queue is ordered by the distance from start plus the estimate h
+// of the remaining distance to goal, and the search stops as soon as goal is settled.
+// h must never overestimate the remaining distance (be admissible), otherwise the path
+// may not be the shortest. If h is also consistent, h(u) <= w(u, v) + h(v) for every
+// edge, no node is expanded twice. In debug mode inconsistent heuristics are reported as an error.
+// h may return infinity for nodes that can not reach goal. Such nodes are never queued,
+// which prunes dead ends early; a node on every path to goal with an infinite estimate means no path.
+// A heuristic that always returns 0 makes A* behave exactly like Dijkstra.
+//
+// Time Complexity: O((V + E) log V), usually far less with a good heuristic
+// returns the nodes on the shortest path from start to goal and its cost
+func AStar[N comparable, W Number](g Reader[N, W], start, goal N, h func(N) W) ([]N, W, error) {
+	return AStarWithQueue[N, W](g, start, goal, h, newMinQueue[W])
+}
+
+// AStarWithQueue is AStar with the min queue returned by newQueue for the items 0..n-1.
+// Monotone queues like pq.NewRadix require a consistent heuristic.
+func AStarWithQueue[N comparable, W Number](g Reader[N, W], start, goal N, h func(N) W, newQueue func(n int) pq.Queue[W]) ([]N, W, error) {
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, infinity[W](), errors.New("start node is not part of the graph")
+	}
+	t, ok := d.index(goal)
+	if !ok {
+		return nil, infinity[W](), errors.New("goal node is not part of the graph")
+	}
+
+	tree, err := astar[N, W](d, s, t, h, newQueue(d.len()))
+	if err != nil {
+		return nil, infinity[W](), err
+	}
+	path, cost, ok := tree.PathTo(goal)
+	if !ok {
+		return nil, infinity[W](), ErrNoPath
+	}
+	return path, cost, nil
+}
+
+// astar searches from s until t is settled. The returned tree is only complete for t
+// and the nodes on its path, all other distances are upper bounds.
+func astar[N comparable, W Number](d denseView[N, W], s, t int, h func(N) W, mq pq.Queue[W]) (*ShortestPathTreeOf[N, W], error) {
+	tree := newShortestPathTree(d, s)
+	distances, pre := tree.dist, tree.pre
+	// estimates are computed once per node, h may be expensive
+	estimates := make([]W, d.len())
+	known := make([]bool, d.len())
+	estimate := func(v int) W {
+		if !known[v] {
+			estimates[v] = h(d.node(v))
+			known[v] = true
+		}
+		return estimates[v]
+	}
+
+	if debug && estimate(t) != 0 {
+		return nil, fmt.Errorf("heuristic is not consistent: h(%v) = %v, expected 0 at the goal", d.node(t), estimate(t))
+	}
+
+	inf := infinity[W]()
+	if estimate(s) == inf {
+		return tree, nil
+	}
+	mq.Push(s, estimate(s))
+	for mq.Len() > 0 {
+		u, _ := mq.Pop()
+		if u == t {
+			break
+		}
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if weights[j] < 0 {
+				return nil, errors.New("a* does not support negative edge weights")
+			}
+			// the goal can not be reached from v
+			if estimate(v) == inf {
+				continue
+			}
+			if debug && estimate(u) > weights[j]+estimate(v) {
+				return nil, fmt.Errorf("heuristic is not consistent: h(%v) = %v > w(%v, %v) + h(%v) = %v",
+					d.node(u), estimate(u), d.node(u), d.node(v), d.node(v), weights[j]+estimate(v))
+			}
+			// with an inconsistent heuristic settled nodes can still improve, they are queued again
+			alt := distances[u] + weights[j]
+			if alt < distances[v] {
+				distances[v] = alt
+				pre[v] = u
+				mq.Push(v, alt+estimate(v))
+			}
+		}
+	}
+
+	return tree, nil
+}
+
+func (g *GraphOf[N, W]) AStar(start, goal N, h func(N) W) ([]N, W, error) {
+	return AStar[N, W](g, start, goal, h)
+}
+
+func (g *GraphOf[N, W]) AStarWithQueue(start, goal N, h func(N) W, newQueue func(n int) pq.Queue[W]) ([]N, W, error) {
+	return AStarWithQueue[N, W](g, start, goal, h, newQueue)
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAStar(t *testing.T) {
+	adjList := AdjList{
+		0: []WeightTuple{{1, 7}, {2, 12}},
+		1: []WeightTuple{{2, 2}, {3, 9}},
+		2: []WeightTuple{{4, 10}},
+		3: []WeightTuple{{5, 1}},
+		4: []WeightTuple{{3, 4}, {5, 5}},
+		5: []WeightTuple{},
+	}
+	g := FromAdjList(adjList)
+
+	path, cost, err := g.AStar(0, 5, func(int) float64 { return 0 })
+	if err != nil {
+		t.Error(err)
+	}
+	expect := []int{0, 1, 3, 5}
+	if !reflect.DeepEqual(path, expect) || cost != 17 {
+		t.Errorf("expected %v with cost 17, got %v with cost %v", expect, path, cost)
+	}
+
+	path, cost, err = g.AStar(3, 3, func(int) float64 { return 0 })
+	if err != nil || !reflect.DeepEqual(path, []int{3}) || cost != 0 {
+		t.Errorf("expected the path [3] with cost 0, got %v with cost %v", path, cost)
+	}
+
+	if _, _, err := g.AStar(5, 0, func(int) float64 { return 0 }); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+	if _, _, err := g.AStar(0, 42, func(int) float64 { return 0 }); err == nil {
+		t.Errorf("expected an error for a goal that is not in the graph")
+	}
+}
+
+// countingGrid counts how many cells were expanded
+type countingGrid struct {
+	grid
+	expanded *int
+}
+
+func (g countingGrid) Neighbors(v cell) []WeightTupleOf[cell, int] {
+	*g.expanded++
+	return g.grid.Neighbors(v)
+}
+
+func TestAStarGrid(t *testing.T) {
+	expanded := 0
+	g := countingGrid{grid{20, 20}, &expanded}
+	start, goal := cell{0, 0}, cell{0, 19}
+	manhattan := func(c cell) int {
+		dr, dc := goal.row-c.row, goal.col-c.col
+		if dr < 0 {
+			dr = -dr
+		}
+		if dc < 0 {
+			dc = -dc
+		}
+		return dr + dc
+	}
+
+	path, cost, err := AStar[cell, int](g, start, goal, manhattan)
+	if err != nil {
+		t.Error(err)
+	}
+	if cost != 19 || len(path) != 20 || path[0] != start || path[19] != goal {
+		t.Errorf("expected a straight path with cost 19, got %v with cost %d", path, cost)
+	}
+	if expanded >= 400 {
+		t.Errorf("expected A* to expand only a part of the grid, expanded %d cells", expanded)
+	}
+
+	tree, err := Dijkstra[cell, int](g.grid, start)
+	if err != nil {
+		t.Error(err)
+	}
+	if tree.DistTo(goal) != cost {
+		t.Errorf("expected the same cost as Dijkstra, got %d and %d", cost, tree.DistTo(goal))
+	}
+}
+
+func TestAStarInconsistentHeuristic(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(0, 2, 3)
+	// admissible, but h(0) > w(0, 1) + h(1)
+	h := func(v int) float64 { return map[int]float64{0: 2, 1: 0, 2: 0}[v] }
+
+	defer func(old bool) { debug = old }(debug)
+	debug = false
+	path, cost, err := g.AStar(0, 2, h)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(path, []int{0, 1, 2}) || cost != 2 {
+		t.Errorf("expected [0 1 2] with cost 2, got %v with cost %v", path, cost)
+	}
+
+	debug = true
+	if _, _, err := g.AStar(0, 2, h); err == nil {
+		t.Errorf("expected an error for an inconsistent heuristic in debug mode")
+	}
+}
+
+// Example Graph:
+// ┌─────┐     1     ┌─────┐     1     ┌─────┐
+// │  0  ├──────────►│  1  ├──────────►│  3  │
+// └──┬──┘           └─────┘           └─────┘
+// .  │ 1
+// .  ▼
+// ┌─────┐     1     ┌─────┐
+// │  2  ├──────────►│  4  │
+// └─────┘           └─────┘
+func TestAStarInfiniteHeuristic(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 4, 1)
+	inf := infinity[int]()
+
+	// 2 and 4 can not reach 3, so they are never queued
+	h := func(v int) int {
+		if v == 2 || v == 4 {
+			return inf
+		}
+		return 0
+	}
+	defer func(old bool) { debug = old }(debug)
+	debug = true
+	path, cost, err := g.AStar(0, 3, h)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(path, []int{0, 1, 3}) || cost != 2 {
+		t.Errorf("expected [0 1 3] with cost 2, got %v with cost %v", path, cost)
+	}
+
+	// an infinite estimate at the start or on every path means there is no path
+	if _, _, err := g.AStar(2, 3, h); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath from 2, got %v", err)
+	}
+	blocked := func(v int) int {
+		if v == 1 {
+			return inf
+		}
+		return 0
+	}
+	if _, _, err := g.AStar(0, 3, blocked); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath through 1, got %v", err)
+	}
+}
+
+func TestAStarQueues(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	rnd := rand.New(rand.NewSource(5))
+	for i := 0; i < 2000; i++ {
+		g.AddEdge(rnd.Intn(300), rnd.Intn(300), rnd.Intn(50))
+	}
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	// the zero heuristic is consistent, so the radix heap stays monotone
+	h := func(int) int { return 0 }
+
+	for name, queue := range testQueues {
+		for goal := 0; goal < 300; goal += 7 {
+			_, cost, err := g.AStarWithQueue(0, goal, h, queue)
+			if dist := tree.DistTo(goal); cost != dist || (err != nil) != (dist == infinity[int]()) {
+				t.Errorf("%s: expected cost %d to %d, got %d, %v", name, dist, goal, cost, err)
+			}
+		}
+	}
+}
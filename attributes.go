@@ -0,0 +1,85 @@
+package graph
+
+import "fmt"
+
+// Attributes are key/value pairs attached to a node or an edge,
+// e.g. labels, colors, capacities or timestamps
+type Attributes map[string]any
+
+// Attr returns the attribute key converted to T. It reports false if the
+// attribute is missing or has a different type.
+func Attr[T any](attrs Attributes, key string) (T, bool) {
+	v, ok := attrs[key].(T)
+	return v, ok
+}
+
+// SetNodeAttr sets the attribute key of node v, the node is created if it does not exist
+func (g *GraphOf[N, W]) SetNodeAttr(v N, key string, value any) {
+	g.AddNode(v)
+	if g.nodeAttrs == nil {
+		g.nodeAttrs = make(map[N]Attributes)
+	}
+	if g.nodeAttrs[v] == nil {
+		g.nodeAttrs[v] = make(Attributes)
+	}
+	g.nodeAttrs[v][key] = value
+}
+
+// NodeAttrs returns the attributes of node v. The result must not be modified, use SetNodeAttr instead.
+func (g *GraphOf[N, W]) NodeAttrs(v N) Attributes {
+	return g.nodeAttrs[v]
+}
+
+// SetEdgeAttr sets the attribute key of the edge with the given id.
+// It returns false if there is no such edge.
+func (g *GraphOf[N, W]) SetEdgeAttr(id EdgeID, key string, value any) bool {
+	if _, ok := g.edgeEnds[id]; !ok {
+		return false
+	}
+	if g.edgeAttrs == nil {
+		g.edgeAttrs = make(map[EdgeID]Attributes)
+	}
+	if g.edgeAttrs[id] == nil {
+		g.edgeAttrs[id] = make(Attributes)
+	}
+	g.edgeAttrs[id][key] = value
+	return true
+}
+
+// EdgeAttrs returns the attributes of the edge with the given id.
+// The result must not be modified, use SetEdgeAttr instead.
+func (g *GraphOf[N, W]) EdgeAttrs(id EdgeID) Attributes {
+	return g.edgeAttrs[id]
+}
+
+// WeightedBy returns a copy of the graph whose edge weights are read from the attribute key.
+// All algorithms can then be run on the copy. It fails if an edge has no such attribute of type W.
+func (g *GraphOf[N, W]) WeightedBy(key string) (*GraphOf[N, W], error) {
+	res := g.Clone()
+	for v, adj := range res.AdjacencyList {
+		for i := range adj {
+			id := res.edgeIDs[v][i]
+			w, ok := Attr[W](res.edgeAttrs[id], key)
+			if !ok {
+				ends := res.edgeEnds[id]
+				return nil, fmt.Errorf("edge %v -> %v has no attribute %q of type %T", ends[0], ends[1], key, w)
+			}
+			adj[i].Weight = w
+		}
+	}
+	return res, nil
+}
+
+func cloneAttrs[K comparable](attrs map[K]Attributes) map[K]Attributes {
+	if attrs == nil {
+		return nil
+	}
+	res := make(map[K]Attributes, len(attrs))
+	for k, a := range attrs {
+		res[k] = make(Attributes, len(a))
+		for key, v := range a {
+			res[k][key] = v
+		}
+	}
+	return res
+}
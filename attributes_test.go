@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodeAttributes(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.SetNodeAttr(0, "label", "depot")
+	g.SetNodeAttr(2, "color", "red")
+
+	if !g.HasNode(2) {
+		t.Errorf("expected node 2 to be created")
+	}
+	label, ok := Attr[string](g.NodeAttrs(0), "label")
+	if !ok || label != "depot" {
+		t.Errorf("expected label depot, got %v", g.NodeAttrs(0))
+	}
+	if _, ok := Attr[int](g.NodeAttrs(0), "label"); ok {
+		t.Errorf("expected attribute of the wrong type to be rejected")
+	}
+	if _, ok := Attr[string](g.NodeAttrs(1), "label"); ok {
+		t.Errorf("expected node 1 to have no label")
+	}
+
+	g.RemoveNode(0)
+	if g.NodeAttrs(0) != nil {
+		t.Errorf("expected attributes to be removed with the node, got %v", g.NodeAttrs(0))
+	}
+}
+
+func TestEdgeAttributes(t *testing.T) {
+	g := NewGraph()
+	id := g.AddEdge(0, 1, 1)
+
+	if !g.SetEdgeAttr(id, "capacity", 10.0) {
+		t.Errorf("expected attribute to be set")
+	}
+	if g.SetEdgeAttr(id+1, "capacity", 10.0) {
+		t.Errorf("expected setting an attribute of a missing edge to fail")
+	}
+	capacity, ok := Attr[float64](g.EdgeAttrs(id), "capacity")
+	if !ok || capacity != 10 {
+		t.Errorf("expected capacity 10, got %v", g.EdgeAttrs(id))
+	}
+
+	g.RemoveEdge(0, 1)
+	if g.EdgeAttrs(id) != nil {
+		t.Errorf("expected attributes to be removed with the edge, got %v", g.EdgeAttrs(id))
+	}
+}
+
+func TestAttributesSurviveCopies(t *testing.T) {
+	g := NewGraph()
+	a := g.AddEdge(0, 1, 1)
+	b := g.AddEdge(1, 2, 1)
+	g.SetNodeAttr(1, "label", "hub")
+	g.SetEdgeAttr(a, "color", "red")
+	g.SetEdgeAttr(b, "color", "blue")
+
+	c := g.Clone()
+	c.SetNodeAttr(1, "label", "changed")
+	if label, _ := Attr[string](g.NodeAttrs(1), "label"); label != "hub" {
+		t.Errorf("expected the clone to have its own attributes, got %v", label)
+	}
+	if !reflect.DeepEqual(c.EdgeAttrs(a), Attributes{"color": "red"}) {
+		t.Errorf("expected edge attributes to be cloned, got %v", c.EdgeAttrs(a))
+	}
+
+	tg := g.Transpose()
+	if e := tg.EdgeByID(a); e == nil || e.From != 1 || e.To != 0 {
+		t.Errorf("expected edge %d to be reversed, got %v", a, e)
+	}
+	if !reflect.DeepEqual(tg.EdgeAttrs(a), Attributes{"color": "red"}) {
+		t.Errorf("expected edge attributes to be transposed, got %v", tg.EdgeAttrs(a))
+	}
+	if !reflect.DeepEqual(tg.NodeAttrs(1), Attributes{"label": "hub"}) {
+		t.Errorf("expected node attributes to be transposed, got %v", tg.NodeAttrs(1))
+	}
+
+	sg := g.Subgraph([]int{1, 2})
+	if sg.NumNodes() != 2 || sg.NumEdges() != 1 {
+		t.Errorf("expected 2 nodes and 1 edge, got %d and %d", sg.NumNodes(), sg.NumEdges())
+	}
+	if sg.EdgeAttrs(a) != nil {
+		t.Errorf("expected edge %d to not be part of the subgraph", a)
+	}
+	if !reflect.DeepEqual(sg.EdgeAttrs(b), Attributes{"color": "blue"}) {
+		t.Errorf("expected edge attributes in the subgraph, got %v", sg.EdgeAttrs(b))
+	}
+	if !reflect.DeepEqual(sg.NodeAttrs(1), Attributes{"label": "hub"}) {
+		t.Errorf("expected node attributes in the subgraph, got %v", sg.NodeAttrs(1))
+	}
+}
+
+func TestWeightedBy(t *testing.T) {
+	g := NewGraph()
+	a := g.AddEdge(0, 1, 1)
+	b := g.AddEdge(1, 2, 1)
+	c := g.AddEdge(0, 2, 1)
+	g.SetEdgeAttr(a, "time", 2.0)
+	g.SetEdgeAttr(b, "time", 3.0)
+
+	if _, err := g.WeightedBy("time"); err == nil {
+		t.Errorf("expected an error for an edge without the attribute")
+	}
+
+	g.SetEdgeAttr(c, "time", 10.0)
+	wg, err := g.WeightedBy("time")
+	if err != nil {
+		t.Error(err)
+	}
+	tree, err := wg.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	dist := tree.Distances()
+	expected := map[int]float64{0: 0, 1: 2, 2: 5}
+	if !reflect.DeepEqual(dist, expected) {
+		t.Errorf("expected: %v, got: %v", expected, dist)
+	}
+	if g.Edge(0, 2).Weight != 1 {
+		t.Errorf("expected the original graph to keep its weights")
+	}
+}
@@ -1,31 +1,91 @@
 package graph
 
-import (
-	"errors"
-	"math"
-)
-
-func (g *Graph) BellmanFord(start int) ([]float64, error) {
-	numNodes := g.NumNodes()
-	dist := make([]float64, numNodes)
-	for i := 1; i < g.NumNodes(); i++ {
-		dist[i] = math.Inf(1)
+import "errors"
+
+// BellmanFord computes the shortest distances from start to all other nodes.
+// Unlike Dijkstra it supports negative edge weights.
+//
+// returns the shortest path tree rooted at start. If a negative cycle is reachable from start,
+// the error is a *NegativeCycleError with one such cycle and the tree is still returned:
+// nodes that are reachable from a negative cycle have distance -infinity, all others are exact.
+//
+// Time Complexity: O(V * E)
+func BellmanFord[N comparable, W Number](g Reader[N, W], start N) (*ShortestPathTreeOf[N, W], error) {
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+
+	tree := newShortestPathTree(d, s)
+	if relaxed := relaxArcs(d, tree.dist, tree.pre, d.len()-1); len(relaxed) > 0 {
+		return tree, unbounded(d, tree, relaxed)
 	}
-	dist[start] = 0
 
-	for i := 0; i < g.NumNodes()-1; i++ {
-		for _, e := range g.Edges() {
-			if dist[e.From] != math.Inf(1) && dist[e.To] > dist[e.From]+e.Weight {
-				dist[e.To] = dist[e.From] + e.Weight
+	return tree, nil
+}
+
+// relaxArcs runs up to rounds passes of Bellman-Ford over all arcs, plus one more pass to detect
+// negative cycles, and stops early once a pass changes nothing. It returns the nodes that were
+// still relaxed in the extra pass, which is empty unless a negative cycle is reachable from a node
+// with finite distance. Every such cycle has at least one of its nodes in the result.
+func relaxArcs[N comparable, W Number](d denseView[N, W], dist []W, pre []int, rounds int) []int {
+	inf := infinity[W]()
+	for i := 0; i <= rounds; i++ {
+		relaxed := make([]int, 0)
+		for from := 0; from < d.len(); from++ {
+			if dist[from] == inf {
+				continue
+			}
+			targets, weights := d.arcs(from)
+			for j, to := range targets {
+				if dist[to] > dist[from]+weights[j] {
+					dist[to] = dist[from] + weights[j]
+					pre[to] = from
+					relaxed = append(relaxed, to)
+				}
 			}
 		}
+		if len(relaxed) == 0 || i == rounds {
+			return relaxed
+		}
 	}
+	return nil
+}
+
+// unbounded extracts a negative cycle and marks every node reachable from one as unbounded,
+// relaxed are the nodes that relaxArcs still relaxed in its extra pass
+func unbounded[N comparable, W Number](d denseView[N, W], tree *ShortestPathTreeOf[N, W], relaxed []int) error {
+	err := negativeCycle(d, tree.pre, relaxed[0])
+	markUnbounded(d, tree, relaxed)
+	return err
+}
 
-	for _, e := range g.Edges() {
-		if dist[e.From] != math.Inf(1) && dist[e.To] > dist[e.From]+e.Weight {
-			return nil, errors.New("Graph contains negative cycle")
+// markUnbounded sets the distance of every node reachable from the given nodes to -infinity
+func markUnbounded[N comparable, W Number](d denseView[N, W], tree *ShortestPathTreeOf[N, W], from []int) {
+	negInf := negativeInfinity[W]()
+	stack := make([]int, 0, len(from))
+	for _, v := range from {
+		if tree.dist[v] != negInf {
+			tree.dist[v] = negInf
+			stack = append(stack, v)
 		}
 	}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		// there is no shortest path, so there is no predecessor either
+		tree.pre[u] = -1
+		targets, _ := d.arcs(u)
+		for _, v := range targets {
+			if tree.dist[v] != negInf {
+				tree.dist[v] = negInf
+				stack = append(stack, v)
+			}
+		}
+	}
+}
 
-	return dist, nil
+func (g *GraphOf[N, W]) BellmanFord(start N) (*ShortestPathTreeOf[N, W], error) {
+	return BellmanFord[N, W](g, start)
 }
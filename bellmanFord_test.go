@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -30,13 +32,87 @@ func TestBellmanFord(t *testing.T) {
 	g.AddEdge(3, 1, 1)
 	g.AddEdge(4, 3, -3)
 
-	dist, err := g.BellmanFord(0)
+	tree, err := g.BellmanFord(0)
 	if err != nil {
 		t.Error(err)
 	}
+	dist := tree.Distances()
 
-	expect := []float64{0, -1, 2, -2, 1}
+	expect := map[int]float64{0: 0, 1: -1, 2: 2, 3: -2, 4: 1}
 	if !reflect.DeepEqual(dist, expect) {
 		t.Errorf("expected: %v, got: %v", expect, dist)
 	}
 }
+
+func TestBellmanFordSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 4)
+	g.AddEdge(100, 7000, -2)
+	g.AddEdge(3, 7000, 5)
+	g.AddNode(42)
+
+	tree, err := g.BellmanFord(3)
+	if err != nil {
+		t.Error(err)
+	}
+	dist := tree.Distances()
+
+	expect := map[int]float64{3: 0, 100: 4, 7000: 2, 42: math.Inf(1)}
+	if !reflect.DeepEqual(dist, expect) {
+		t.Errorf("expected: %v, got: %v", expect, dist)
+	}
+}
+
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -2)
+	g.AddEdge(2, 1, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(0, 4, 5)
+	g.AddNode(5)
+
+	tree, err := g.BellmanFord(0)
+	var cycleErr *NegativeCycleError[int, float64]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a NegativeCycleError, got %v", err)
+	}
+	if len(cycleErr.Cycle) != 2 || cycleErr.Weight != -1 {
+		t.Errorf("expected the cycle 1 -> 2 -> 1 with weight -1, got %v with weight %v", cycleErr.Cycle, cycleErr.Weight)
+	}
+	for i, u := range cycleErr.Cycle {
+		if !g.HasEdge(u, cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]) {
+			t.Errorf("expected %v to be a cycle", cycleErr.Cycle)
+		}
+	}
+
+	for _, v := range []int{1, 2, 3} {
+		if !tree.Unbounded(v) || !math.IsInf(tree.DistTo(v), -1) {
+			t.Errorf("expected %d to be unbounded", v)
+		}
+		if _, _, ok := tree.PathTo(v); ok {
+			t.Errorf("expected no shortest path to %d", v)
+		}
+	}
+	if tree.Unbounded(0) || tree.DistTo(0) != 0 || tree.DistTo(4) != 5 {
+		t.Errorf("expected exact distances for 0 and 4, got %v", tree.Distances())
+	}
+	if tree.Reachable(5) {
+		t.Errorf("expected 5 to be unreachable")
+	}
+}
+
+func TestBellmanFordUnreachableNegativeCycle(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(2, 3, -1)
+	g.AddEdge(3, 2, -1)
+
+	tree, err := g.BellmanFord(0)
+	if err != nil {
+		t.Errorf("expected a negative cycle that is not reachable to be ignored, got %v", err)
+	}
+	if tree.DistTo(1) != 3 || tree.Reachable(2) {
+		t.Errorf("expected only 1 to be reachable, got %v", tree.Distances())
+	}
+}
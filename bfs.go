@@ -7,36 +7,51 @@ package graph
 //
 // Time Complexity: O(V + E)
 // Space Complexity: O(V)
-func (g *Graph) BFS(start int, fn func(int)) {
-	numNodes := g.NumNodes()
-	visited := make([]bool, numNodes)
-	g.BFSstep(start, visited, fn)
+func BFS[N comparable, W Number](g Reader[N, W], start N, fn func(N)) {
+	d := viewOf(g)
+	visited := make([]bool, d.len())
+	if s, ok := d.index(start); ok {
+		bfsStep(d, s, visited, fn)
+	}
 
 	// If the graph is not connected, we will start exploring the remaining graph components
-	for i := 0; i < numNodes; i++ {
+	for i := range visited {
 		if !visited[i] {
-			g.BFSstep(i, visited, fn)
+			bfsStep(d, i, visited, fn)
 		}
 	}
 }
 
+func (g *GraphOf[N, W]) BFS(start N, fn func(N)) {
+	BFS[N, W](g, start, fn)
+}
+
 // Breadth First Step
 //
-// Perfomes a breadth first search step starting at node start
-func (g *Graph) BFSstep(start int, visited []bool, fn func(int)) {
+// Perfomes a breadth first search step starting at node start.
+// visited is indexed by the position of a node in g.Nodes()
+func (g *GraphOf[N, W]) BFSstep(start N, visited []bool, fn func(N)) {
+	d := viewOf[N, W](g)
+	if s, ok := d.index(start); ok {
+		bfsStep(d, s, visited, fn)
+	}
+}
+
+func bfsStep[N comparable, W Number](d denseView[N, W], start int, visited []bool, fn func(N)) {
 	queue := make([]int, 0)
 	queue = append(queue, start)
 	visited[start] = true
-	fn(start)
+	fn(d.node(start))
 
 	for len(queue) > 0 {
 		curr := queue[0]
 		queue = queue[1:]
-		for _, edge := range g.AdjEdges(curr) {
-			if !visited[edge.To] {
-				queue = append(queue, edge.To)
-				visited[edge.To] = true
-				fn(edge.To)
+		targets, _ := d.arcs(curr)
+		for _, to := range targets {
+			if !visited[to] {
+				queue = append(queue, to)
+				visited[to] = true
+				fn(d.node(to))
 			}
 		}
 	}
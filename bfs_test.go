@@ -165,3 +165,19 @@ func TestBFSDisconnected(t *testing.T) {
 		t.Errorf("expected [0, 2, 3, 1], got %v", res)
 	}
 }
+
+func TestBFSSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 1)
+	g.AddEdge(3, 7000, 1)
+	g.AddEdge(7000, 8, 1)
+	g.AddNode(42)
+
+	res := make([]int, 0)
+	g.BFS(3, func(n int) {
+		res = append(res, n)
+	})
+	if !reflect.DeepEqual(res, []int{3, 100, 7000, 8, 42}) {
+		t.Errorf("expected [3, 100, 7000, 8, 42], got %v", res)
+	}
+}
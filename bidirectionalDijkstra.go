@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/timHau/graph/pq"
+)
+
+// Bidirectional Dijkstra for a single shortest path
+//
+// Runs one search forward from start and one backward from goal on the transpose of the graph,
+// always advancing the side with the smaller queue minimum. Every arc that connects the two
+// searches is a candidate path. Once the two queue minima add up to at least the best candidate,
+// no shorter path can exist and the search stops. On road like graphs this settles far fewer
+// nodes than Dijkstra, which grows a single ball around start.
+//
+// Time Complexity: O((V + E) log V)
+// returns the nodes on the shortest path from start to goal and its cost
+func BidirectionalDijkstra[N comparable, W Number](g Reader[N, W], start, goal N) ([]N, W, error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, infinity[W](), errors.New("dijkstras Algorithm does not support negative edge weights")
+	}
+	s, ok := d.index(start)
+	if !ok {
+		return nil, infinity[W](), errors.New("start node is not part of the graph")
+	}
+	t, ok := d.index(goal)
+	if !ok {
+		return nil, infinity[W](), errors.New("goal node is not part of the graph")
+	}
+
+	var backward denseView[N, W] = d
+	if g.IsDirected() {
+		backward = transposeOf(d)
+	}
+	path, cost, _ := bidirectional(d, backward, s, t)
+	if path == nil {
+		return nil, infinity[W](), ErrNoPath
+	}
+	res := make([]N, len(path))
+	for i, v := range path {
+		res[i] = d.node(v)
+	}
+	return res, cost, nil
+}
+
+// searchSide is the state of one direction of a bidirectional search
+type searchSide[N comparable, W Number] struct {
+	d    denseView[N, W]
+	tree *ShortestPathTreeOf[N, W]
+	mq   pq.Queue[W]
+}
+
+func newSearchSide[N comparable, W Number](d denseView[N, W], source int) *searchSide[N, W] {
+	side := &searchSide[N, W]{d, newShortestPathTree(d, source), pq.NewMin[W](d.len())}
+	side.mq.Push(source, 0)
+	return side
+}
+
+// bidirectional returns the dense shortest path from s to t, its cost and the number of settled nodes.
+// The path is nil if t is not reachable.
+func bidirectional[N comparable, W Number](forward, backward denseView[N, W], s, t int) ([]int, W, int) {
+	inf := infinity[W]()
+	fw, bw := newSearchSide(forward, s), newSearchSide(backward, t)
+	// best is the length of the shortest path found so far, it goes through the arc meetFrom -> meetTo
+	best, meetFrom, meetTo := inf, -1, -1
+	if s == t {
+		best, meetFrom, meetTo = 0, s, s
+	}
+	settled := 0
+
+	for fw.mq.Len() > 0 && bw.mq.Len() > 0 {
+		_, fMin := fw.mq.Peek()
+		_, bMin := bw.mq.Peek()
+		// every path that is still unknown is at least as long as fMin + bMin
+		if best != inf && fMin+bMin >= best {
+			break
+		}
+
+		side, other := fw, bw
+		if bMin < fMin {
+			side, other = bw, fw
+		}
+		u, _ := side.mq.Pop()
+		settled++
+		dist := side.tree.dist
+		targets, weights := side.d.arcs(u)
+		for j, v := range targets {
+			alt := dist[u] + weights[j]
+			if alt < dist[v] {
+				dist[v] = alt
+				side.tree.pre[v] = u
+				side.mq.Push(v, alt)
+			}
+			if other.tree.dist[v] != inf && alt+other.tree.dist[v] < best {
+				best = alt + other.tree.dist[v]
+				meetFrom, meetTo = u, v
+				if side == bw {
+					meetFrom, meetTo = v, u
+				}
+			}
+		}
+	}
+
+	if meetFrom == -1 {
+		return nil, inf, settled
+	}
+	// walk back from the meeting arc to s, then forward to t
+	path := make([]int, 0)
+	for v := meetFrom; v != -1; v = fw.tree.pre[v] {
+		path = append(path, v)
+	}
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	if meetTo != meetFrom {
+		for v := meetTo; v != -1; v = bw.tree.pre[v] {
+			path = append(path, v)
+		}
+	}
+	return path, best, settled
+}
+
+func (g *GraphOf[N, W]) BidirectionalDijkstra(start, goal N) ([]N, W, error) {
+	return BidirectionalDijkstra[N, W](g, start, goal)
+}
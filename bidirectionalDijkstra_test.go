@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	adjList := AdjList{
+		0: []WeightTuple{{1, 7}, {2, 12}},
+		1: []WeightTuple{{2, 2}, {3, 9}},
+		2: []WeightTuple{{4, 10}},
+		3: []WeightTuple{{5, 1}},
+		4: []WeightTuple{{3, 4}, {5, 5}},
+		5: []WeightTuple{},
+	}
+	g := FromAdjList(adjList)
+
+	path, cost, err := g.BidirectionalDijkstra(0, 5)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := []int{0, 1, 3, 5}
+	if !reflect.DeepEqual(path, expect) || cost != 17 {
+		t.Errorf("expected %v with cost 17, got %v with cost %v", expect, path, cost)
+	}
+
+	path, cost, err = g.BidirectionalDijkstra(4, 4)
+	if err != nil || !reflect.DeepEqual(path, []int{4}) || cost != 0 {
+		t.Errorf("expected the path [4] with cost 0, got %v with cost %v", path, cost)
+	}
+
+	if _, _, err := g.BidirectionalDijkstra(5, 0); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+}
+
+func TestBidirectionalDijkstraMatchesDijkstra(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := randomGraph(200, 1000, 5, undirected, 1000000)
+		for s := 0; s < 200; s += 17 {
+			tree, err := g.Dijkstra(s)
+			if err != nil {
+				t.Error(err)
+			}
+			for goal := 0; goal < 200; goal += 3 {
+				expect, expectCost, reachable := tree.PathTo(goal)
+				path, cost, err := g.BidirectionalDijkstra(s, goal)
+				if !reachable {
+					if !errors.Is(err, ErrNoPath) {
+						t.Errorf("expected no path from %d to %d, got %v", s, goal, path)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(path, expect) || cost != expectCost {
+					t.Errorf("from %d to %d expected %v with cost %v, got %v with cost %v", s, goal, expect, expectCost, path, cost)
+				}
+			}
+		}
+	}
+}
+
+func TestBidirectionalDijkstraSettlesFewerNodes(t *testing.T) {
+	g := randomGrid(100, 100, 9)
+	s, goal := 35*100+35, 65*100+65
+
+	tree, err := g.Dijkstra(s)
+	if err != nil {
+		t.Error(err)
+	}
+	// Dijkstra settles every node that is closer than the goal before it settles the goal
+	dijkstraSettled := 0
+	for _, dist := range tree.Distances() {
+		if dist < tree.DistTo(goal) {
+			dijkstraSettled++
+		}
+	}
+
+	d := viewOf[int, float64](g)
+	si, _ := d.index(s)
+	ti, _ := d.index(goal)
+	_, cost, settled := bidirectional(d, d, si, ti)
+	if cost != tree.DistTo(goal) {
+		t.Errorf("expected cost %v, got %v", tree.DistTo(goal), cost)
+	}
+	// the two balls around start and goal have about half the area of the single ball around start
+	if 10*settled > 6*dijkstraSettled {
+		t.Errorf("expected far fewer settled nodes than Dijkstra, got %d and %d", settled, dijkstraSettled)
+	}
+}
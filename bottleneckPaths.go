@@ -0,0 +1,246 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/timHau/graph/pq"
+)
+
+// BottleneckTreeOf is the result of the widest and minimax path searches. For every node it
+// stores the predecessor on the best path from Source and the bottleneck of that path: the
+// smallest weight on a widest path, or the largest weight on a minimax path.
+type BottleneckTreeOf[N comparable, W Number] struct {
+	Source  N
+	nodes   denseView[N, W]
+	value   []W
+	pre     []int // dense index of the predecessor, -1 for the source and unreachable nodes
+	reached []bool
+}
+
+type BottleneckTree = BottleneckTreeOf[int, float64]
+
+// newBottleneckTree starts a tree at s. The empty path at s has no bottleneck, so its value is
+// infinity for widest paths and -infinity (0 for unsigned weights) for minimax paths.
+func newBottleneckTree[N comparable, W Number](d denseView[N, W], s int, widest bool) *BottleneckTreeOf[N, W] {
+	t := &BottleneckTreeOf[N, W]{
+		Source:  d.node(s),
+		nodes:   d,
+		value:   make([]W, d.len()),
+		pre:     make([]int, d.len()),
+		reached: make([]bool, d.len()),
+	}
+	for i := range t.pre {
+		t.pre[i] = -1
+	}
+	t.value[s] = negativeInfinity[W]()
+	if widest {
+		t.value[s] = infinity[W]()
+	}
+	t.reached[s] = true
+	return t
+}
+
+// WidestPath finds for every node the path from start whose smallest weight is as large as possible,
+// e.g. the route with the most bandwidth when weights are capacities.
+//
+// It is Dijkstra with a max queue, where the value of a path is the minimum instead of the sum
+// of its weights. Negative weights are allowed.
+//
+// Time Complexity: O((V + E) log V)
+func WidestPath[N comparable, W Number](g Reader[N, W], start N) (*BottleneckTreeOf[N, W], error) {
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+	return bottleneckPaths[N, W](d, s, pq.NewMax[W](d.len()), pq.Greater[W], true), nil
+}
+
+// MinimaxPath finds for every node the path from start whose largest weight is as small as possible,
+// e.g. the route that avoids the riskiest link when weights are risks.
+//
+// It is Dijkstra where the value of a path is the maximum instead of the sum of its weights.
+// Negative weights are allowed.
+//
+// Time Complexity: O((V + E) log V)
+func MinimaxPath[N comparable, W Number](g Reader[N, W], start N) (*BottleneckTreeOf[N, W], error) {
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+	return bottleneckPaths[N, W](d, s, pq.NewMin[W](d.len()), pq.Less[W], false), nil
+}
+
+// bottleneckPaths runs the modified Dijkstra from s. better orders bottleneck values like mq orders priorities.
+func bottleneckPaths[N comparable, W Number](d denseView[N, W], s int, mq pq.Queue[W], better func(a, b W) bool, widest bool) *BottleneckTreeOf[N, W] {
+	t := newBottleneckTree(d, s, widest)
+	value, pre, reached := t.value, t.pre, t.reached
+
+	mq.Push(s, value[s])
+	for mq.Len() > 0 {
+		// the best value in the queue is final, extending a path never makes its bottleneck better
+		u, _ := mq.Pop()
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			// the bottleneck of the extended path is the worse of the two
+			alt := weights[j]
+			if better(alt, value[u]) {
+				alt = value[u]
+			}
+			if !reached[v] || better(alt, value[v]) {
+				reached[v] = true
+				value[v] = alt
+				pre[v] = u
+				mq.Push(v, alt)
+			}
+		}
+	}
+
+	return t
+}
+
+// WidestPathMST derives the widest paths of an undirected graph from a maximum spanning tree:
+// the path between two nodes in the tree is a widest path between them. The bottleneck values
+// are the same as those of WidestPath, on ties the paths may differ.
+//
+// Time Complexity: O((V + E) log V)
+func WidestPathMST[N comparable, W Number](g Reader[N, W], start N) (*BottleneckTreeOf[N, W], error) {
+	return spanningTreePaths(g, start, true)
+}
+
+// MinimaxPathMST derives the minimax paths of an undirected graph from a minimum spanning tree:
+// the path between two nodes in the tree is a minimax path between them. The bottleneck values
+// are the same as those of MinimaxPath, on ties the paths may differ.
+//
+// Time Complexity: O((V + E) log V)
+func MinimaxPathMST[N comparable, W Number](g Reader[N, W], start N) (*BottleneckTreeOf[N, W], error) {
+	return spanningTreePaths(g, start, false)
+}
+
+func spanningTreePaths[N comparable, W Number](g Reader[N, W], start N, widest bool) (*BottleneckTreeOf[N, W], error) {
+	if g.IsDirected() {
+		return nil, errors.New("spanning tree paths require an undirected graph")
+	}
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+
+	var parent []int
+	var keys []W
+	better := pq.Less[W]
+	if widest {
+		better = pq.Greater[W]
+		parent, keys = spanningForest[N, W](d, pq.NewMax[W](d.len()), better)
+	} else {
+		parent, keys = spanningForest[N, W](d, pq.NewMin[W](d.len()), better)
+	}
+	children := make([][]int, d.len())
+	for v, p := range parent {
+		if p != -1 {
+			children[p] = append(children[p], v)
+		}
+	}
+
+	// walk the tree of start, the edge between v and its parent has weight keys[v]
+	t := newBottleneckTree(d, s, widest)
+	stack := []int{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		visit := func(v int, w W) {
+			if t.reached[v] {
+				return
+			}
+			if better(w, t.value[u]) {
+				w = t.value[u]
+			}
+			t.reached[v], t.value[v], t.pre[v] = true, w, u
+			stack = append(stack, v)
+		}
+		if parent[u] != -1 {
+			visit(parent[u], keys[u])
+		}
+		for _, v := range children[u] {
+			visit(v, keys[v])
+		}
+	}
+	return t, nil
+}
+
+// Reachable reports whether there is a path from the source to v
+func (t *BottleneckTreeOf[N, W]) Reachable(v N) bool {
+	i, ok := t.nodes.index(v)
+	return ok && t.reached[i]
+}
+
+// Bottleneck returns the bottleneck value of the best path from the source to v,
+// or false if v is not reachable
+func (t *BottleneckTreeOf[N, W]) Bottleneck(v N) (W, bool) {
+	i, ok := t.nodes.index(v)
+	if !ok || !t.reached[i] {
+		var none W
+		return none, false
+	}
+	return t.value[i], true
+}
+
+// PathTo returns the nodes on the best path from the source to v (both included)
+// and its bottleneck value. It reports false if v is not reachable.
+func (t *BottleneckTreeOf[N, W]) PathTo(v N) ([]N, W, bool) {
+	i, ok := t.nodes.index(v)
+	if !ok || !t.reached[i] {
+		var none W
+		return nil, none, false
+	}
+	path := make([]N, 0)
+	for j := i; j != -1; j = t.pre[j] {
+		path = append(path, t.nodes.node(j))
+	}
+	// the path was collected from v back to the source
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path, t.value[i], true
+}
+
+// Bottlenecks returns the bottleneck value of every reachable node
+func (t *BottleneckTreeOf[N, W]) Bottlenecks() map[N]W {
+	res := make(map[N]W, len(t.value))
+	for i, w := range t.value {
+		if t.reached[i] {
+			res[t.nodes.node(i)] = w
+		}
+	}
+	return res
+}
+
+// Predecessors returns the predecessor of every node on its best path.
+// The source and unreachable nodes have none.
+func (t *BottleneckTreeOf[N, W]) Predecessors() map[N]N {
+	res := make(map[N]N, len(t.pre))
+	for i, p := range t.pre {
+		if p != -1 {
+			res[t.nodes.node(i)] = t.nodes.node(p)
+		}
+	}
+	return res
+}
+
+func (g *GraphOf[N, W]) WidestPath(start N) (*BottleneckTreeOf[N, W], error) {
+	return WidestPath[N, W](g, start)
+}
+
+func (g *GraphOf[N, W]) MinimaxPath(start N) (*BottleneckTreeOf[N, W], error) {
+	return MinimaxPath[N, W](g, start)
+}
+
+func (g *GraphOf[N, W]) WidestPathMST(start N) (*BottleneckTreeOf[N, W], error) {
+	return WidestPathMST[N, W](g, start)
+}
+
+func (g *GraphOf[N, W]) MinimaxPathMST(start N) (*BottleneckTreeOf[N, W], error) {
+	return MinimaxPathMST[N, W](g, start)
+}
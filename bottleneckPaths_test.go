@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Example Graph:
+// ┌─────┐       5         ┌─────┐
+// │  0  ├─────────────────┤  1  │
+// └──┬──┘                 └──┬──┘
+// .  │                       │
+// . 1│                      4│
+// .  │                       │
+// ┌──┴──┐       2         ┌──┴──┐       3         ┌─────┐
+// │  2  ├─────────────────┤  3  ├─────────────────┤  4  │
+// └─────┘                 └─────┘                 └─────┘
+func TestWidestPath(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 3, 4)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(3, 4, 3)
+	g.AddNode(5)
+
+	tree, err := g.WidestPath(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[int]float64{0: infinity[float64](), 1: 5, 2: 2, 3: 4, 4: 3}
+	if !reflect.DeepEqual(tree.Bottlenecks(), expect) {
+		t.Errorf("expected bottlenecks %v, got %v", expect, tree.Bottlenecks())
+	}
+	if path, width, ok := tree.PathTo(2); !ok || !reflect.DeepEqual(path, []int{0, 1, 3, 2}) || width != 2 {
+		t.Errorf("expected the path [0 1 3 2] with width 2, got %v with width %v", path, width)
+	}
+	if tree.Reachable(5) {
+		t.Errorf("expected 5 to be unreachable")
+	}
+}
+
+func TestMinimaxPath(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 3, 4)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(3, 4, 3)
+
+	tree, err := g.MinimaxPath(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[int]float64{0: negativeInfinity[float64](), 1: 4, 2: 1, 3: 2, 4: 3}
+	if !reflect.DeepEqual(tree.Bottlenecks(), expect) {
+		t.Errorf("expected bottlenecks %v, got %v", expect, tree.Bottlenecks())
+	}
+	if path, risk, ok := tree.PathTo(1); !ok || !reflect.DeepEqual(path, []int{0, 2, 3, 1}) || risk != 4 {
+		t.Errorf("expected the path [0 2 3 1] with risk 4, got %v with risk %v", path, risk)
+	}
+	if _, ok := tree.Bottleneck(9); ok {
+		t.Errorf("expected no bottleneck for an unknown node")
+	}
+}
+
+func TestBottleneckPathDirected(t *testing.T) {
+	g := NewGraphOf[int, uint]()
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(1, 2, 7)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(2, 0, 9)
+
+	widest, err := g.WidestPath(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, _ := widest.Bottleneck(2); w != 3 {
+		t.Errorf("expected width 3 to 2, got %v", w)
+	}
+	minimax, err := g.MinimaxPath(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, _ := minimax.Bottleneck(2); w != 2 {
+		t.Errorf("expected risk 2 to 2, got %v", w)
+	}
+	if _, err := g.WidestPathMST(0); err == nil {
+		t.Errorf("expected an error for a directed graph")
+	}
+}
+
+func TestBottleneckPathMatchesMST(t *testing.T) {
+	g := randomGraph(200, 600, 8, true, 1000000)
+	g.AddNode(200)
+	c := g.Freeze()
+
+	for _, s := range []int{0, 57, 133} {
+		for _, widest := range []bool{true, false} {
+			search, mst := c.MinimaxPath, c.MinimaxPathMST
+			if widest {
+				search, mst = c.WidestPath, c.WidestPathMST
+			}
+			expect, err := search(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree, err := mst(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tree.Bottlenecks(), expect.Bottlenecks()) {
+				t.Errorf("expected the same bottlenecks from %d for widest = %v", s, widest)
+			}
+
+			// the bottleneck of every path is the one reported
+			for v := 0; v <= 200; v++ {
+				path, value, ok := tree.PathTo(v)
+				if !ok || v == s {
+					continue
+				}
+				bottleneck, _ := c.Weight(path[0], path[1])
+				for i := 2; i < len(path); i++ {
+					w, _ := c.Weight(path[i-1], path[i])
+					if widest && w < bottleneck || !widest && w > bottleneck {
+						bottleneck = w
+					}
+				}
+				if bottleneck != value {
+					t.Errorf("expected the path %v to have the bottleneck %v, got %v", path, value, bottleneck)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,565 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+
+	"github.com/timHau/graph/pq"
+)
+
+// ContractionHierarchyOf is a preprocessed graph for fast shortest path queries.
+//
+// Nodes are contracted one after another, least important first. Contracting v removes it and adds
+// a shortcut u -> x for every path u -> v -> x, unless a witness search finds a path that is no longer.
+// The rank of a node is its position in that order. Every shortest path then has a version that
+// first only goes up in rank and then only goes down, so a query is a bidirectional Dijkstra that
+// only follows arcs to higher ranked nodes and settles a tiny part of the graph.
+//
+// The hierarchy is immutable and can be stored with MarshalBinary.
+type ContractionHierarchyOf[N comparable, W Number] struct {
+	nodes []N
+	pos   map[N]int
+	rank  []int
+	// up holds the arcs u -> v with rank(v) > rank(u) at u, down the arcs u -> v with rank(u) > rank(v) at v
+	up   [][]chArc[W]
+	down [][]chArc[W]
+}
+
+type ContractionHierarchy = ContractionHierarchyOf[int, float64]
+
+// chArc is an arc of the hierarchy. To is the other end, for down arcs that is the source.
+type chArc[W Number] struct {
+	To     int
+	Weight W
+	// Via is the contracted node a shortcut skips, -1 for the arcs of the graph
+	Via int
+}
+
+// witnessLimit bounds the number of nodes a witness search settles. If no witness is found
+// in time a shortcut is added, which is never wrong, only a bit slower to query.
+// The priorities only need an estimate of the number of shortcuts, so they search less.
+const (
+	witnessLimit         = 500
+	priorityWitnessLimit = 50
+)
+
+// NewContractionHierarchy contracts all nodes of g, ordered by the number of shortcuts their
+// contraction adds minus the number of arcs it removes, plus the number of already contracted neighbors.
+//
+// Time Complexity: depends on the graph, close to O(V log V) for road networks
+func NewContractionHierarchy[N comparable, W Number](g Reader[N, W]) (*ContractionHierarchyOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("contraction hierarchies do not support negative edge weights")
+	}
+
+	numNodes := d.len()
+	b := &chBuilder[W]{
+		out:       make([][]chArc[W], numNodes),
+		in:        make([][]chArc[W], numNodes),
+		up:        make([][]chArc[W], numNodes),
+		down:      make([][]chArc[W], numNodes),
+		neighbors: make([]int, numNodes),
+		dist:      make([]W, numNodes),
+		seen:      make([]int, numNodes),
+		target:    make([]int, numNodes),
+	}
+	for u := 0; u < numNodes; u++ {
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if u != v {
+				b.addArc(u, v, weights[j], -1)
+			}
+		}
+	}
+
+	rank := make([]int, numNodes)
+	mq := pq.NewMin[int](numNodes)
+	for v := 0; v < numNodes; v++ {
+		mq.Push(v, b.priority(v))
+	}
+	for r := 0; mq.Len() > 0; {
+		v, _ := mq.Pop()
+		// contracting other nodes changed the priority, pick the next node if v is not the best anymore
+		if p := b.priority(v); mq.Len() > 0 {
+			if _, next := mq.Peek(); p > next {
+				mq.Push(v, p)
+				continue
+			}
+		}
+		b.contract(v)
+		rank[v] = r
+		r++
+	}
+
+	ch := &ContractionHierarchyOf[N, W]{
+		nodes: make([]N, numNodes),
+		rank:  rank,
+		up:    b.up,
+		down:  b.down,
+	}
+	for u := range ch.nodes {
+		ch.nodes[u] = d.node(u)
+	}
+	ch.index()
+	return ch, nil
+}
+
+func (g *GraphOf[N, W]) ContractionHierarchy() (*ContractionHierarchyOf[N, W], error) {
+	return NewContractionHierarchy[N, W](g)
+}
+
+func (c *CSROf[N, W]) ContractionHierarchy() (*ContractionHierarchyOf[N, W], error) {
+	return NewContractionHierarchy[N, W](c)
+}
+
+// chBuilder holds the remaining graph while it is contracted. A contracted node moves its
+// arcs to up and down, they all lead to nodes contracted later.
+type chBuilder[W Number] struct {
+	out, in   [][]chArc[W] // in[v] holds the arc u -> v with To = u
+	up, down  [][]chArc[W]
+	neighbors []int // number of contracted neighbors of every node
+
+	// buffers of the witness searches, dist[v] is only valid if seen[v] == search
+	// and v is a target of the search if target[v] == search
+	dist   []W
+	seen   []int
+	target []int
+	search int
+	heap   witnessHeap[W]
+}
+
+// addArc adds the arc u -> v, or makes an existing one lighter
+func (b *chBuilder[W]) addArc(u, v int, w W, via int) {
+	for i, a := range b.out[u] {
+		if a.To == v {
+			if w < a.Weight {
+				b.out[u][i] = chArc[W]{v, w, via}
+				for j, back := range b.in[v] {
+					if back.To == u {
+						b.in[v][j] = chArc[W]{u, w, via}
+					}
+				}
+			}
+			return
+		}
+	}
+	b.out[u] = append(b.out[u], chArc[W]{v, w, via})
+	b.in[v] = append(b.in[v], chArc[W]{u, w, via})
+}
+
+// removeArc removes the arc to v from arcs
+func removeArc[W Number](arcs []chArc[W], v int) []chArc[W] {
+	for i, a := range arcs {
+		if a.To == v {
+			return append(arcs[:i], arcs[i+1:]...)
+		}
+	}
+	return arcs
+}
+
+func (b *chBuilder[W]) priority(v int) int {
+	return b.shortcuts(v, false) - len(b.out[v]) - len(b.in[v]) + b.neighbors[v]
+}
+
+// contract removes v from the remaining graph and adds the shortcuts it needs
+func (b *chBuilder[W]) contract(v int) {
+	b.shortcuts(v, true)
+	b.up[v], b.down[v] = b.out[v], b.in[v]
+	for _, a := range b.out[v] {
+		b.in[a.To] = removeArc(b.in[a.To], v)
+		b.neighbors[a.To]++
+	}
+	for _, a := range b.in[v] {
+		b.out[a.To] = removeArc(b.out[a.To], v)
+		b.neighbors[a.To]++
+	}
+	b.out[v], b.in[v] = nil, nil
+}
+
+// shortcuts returns the number of shortcuts needed to contract v, with add set it also adds them
+func (b *chBuilder[W]) shortcuts(v int, add bool) int {
+	shortcuts := 0
+	for _, in := range b.in[v] {
+		u := in.To
+		var limit W
+		b.search++
+		targets := 0
+		for _, out := range b.out[v] {
+			if out.To != u {
+				if in.Weight+out.Weight > limit {
+					limit = in.Weight + out.Weight
+				}
+				b.target[out.To] = b.search
+				targets++
+			}
+		}
+		if targets == 0 {
+			continue
+		}
+		settleLimit := priorityWitnessLimit
+		if add {
+			settleLimit = witnessLimit
+		}
+		b.witnessSearch(u, v, limit, targets, settleLimit)
+		for _, out := range b.out[v] {
+			x := out.To
+			if x == u || b.seen[x] == b.search && b.dist[x] <= in.Weight+out.Weight {
+				continue
+			}
+			shortcuts++
+			if add {
+				b.addArc(u, x, in.Weight+out.Weight, v)
+			}
+		}
+	}
+	return shortcuts
+}
+
+// witnessSearch runs a small Dijkstra from u in the remaining graph without v,
+// and leaves the distances it found up to limit in b.dist. It stops once all targets are settled.
+func (b *chBuilder[W]) witnessSearch(u, v int, limit W, targets, settleLimit int) {
+	b.dist[u], b.seen[u] = 0, b.search
+	b.heap = append(b.heap[:0], witnessEntry[W]{u, 0})
+	for settled := 0; len(b.heap) > 0 && settled < settleLimit && targets > 0; {
+		e := b.heap.pop()
+		if e.dist > limit {
+			break
+		}
+		if e.dist > b.dist[e.node] {
+			continue
+		}
+		settled++
+		if b.target[e.node] == b.search {
+			targets--
+		}
+		for _, a := range b.out[e.node] {
+			if a.To == v {
+				continue
+			}
+			if alt := e.dist + a.Weight; b.seen[a.To] != b.search || alt < b.dist[a.To] {
+				b.dist[a.To], b.seen[a.To] = alt, b.search
+				b.heap.push(witnessEntry[W]{a.To, alt})
+			}
+		}
+	}
+}
+
+// witnessHeap is a plain binary heap for the witness searches, which only touch a few nodes
+// each, so they can not afford to reset an indexed queue over all nodes
+type witnessEntry[W Number] struct {
+	node int
+	dist W
+}
+
+type witnessHeap[W Number] []witnessEntry[W]
+
+func (h *witnessHeap[W]) push(e witnessEntry[W]) {
+	*h = append(*h, e)
+	q := *h
+	for i := len(q) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if q[parent].dist <= q[i].dist {
+			break
+		}
+		q[parent], q[i] = q[i], q[parent]
+		i = parent
+	}
+}
+
+func (h *witnessHeap[W]) pop() witnessEntry[W] {
+	q := *h
+	top := q[0]
+	last := len(q) - 1
+	q[0] = q[last]
+	q = q[:last]
+	for i := 0; ; {
+		next := i
+		if l := 2*i + 1; l < len(q) && q[l].dist < q[next].dist {
+			next = l
+		}
+		if r := 2*i + 2; r < len(q) && q[r].dist < q[next].dist {
+			next = r
+		}
+		if next == i {
+			break
+		}
+		q[i], q[next] = q[next], q[i]
+		i = next
+	}
+	*h = q
+	return top
+}
+
+func (ch *ContractionHierarchyOf[N, W]) index() {
+	ch.pos = make(map[N]int, len(ch.nodes))
+	for i, v := range ch.nodes {
+		ch.pos[v] = i
+	}
+}
+
+// NumShortcuts returns the number of shortcuts the contraction added
+func (ch *ContractionHierarchyOf[N, W]) NumShortcuts() int {
+	res := 0
+	for _, arcs := range ch.up {
+		for _, a := range arcs {
+			if a.Via != -1 {
+				res++
+			}
+		}
+	}
+	for _, arcs := range ch.down {
+		for _, a := range arcs {
+			if a.Via != -1 {
+				res++
+			}
+		}
+	}
+	return res
+}
+
+// ShortestPath answers a single query. Use NewQuery to answer many queries without allocations.
+func (ch *ContractionHierarchyOf[N, W]) ShortestPath(start, goal N) ([]N, W, error) {
+	return ch.NewQuery().ShortestPath(start, goal)
+}
+
+// chData is the serialized form of a hierarchy
+type chData[N comparable, W Number] struct {
+	Nodes    []N
+	Rank     []int
+	Up, Down [][]chArc[W]
+}
+
+// MarshalBinary encodes the hierarchy with encoding/gob, so N must be encodable by gob
+func (ch *ContractionHierarchyOf[N, W]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(chData[N, W]{ch.nodes, ch.rank, ch.up, ch.down})
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary restores a hierarchy encoded by MarshalBinary
+func (ch *ContractionHierarchyOf[N, W]) UnmarshalBinary(data []byte) error {
+	var res chData[N, W]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&res); err != nil {
+		return err
+	}
+	if len(res.Rank) != len(res.Nodes) || len(res.Up) != len(res.Nodes) || len(res.Down) != len(res.Nodes) {
+		return errors.New("corrupt contraction hierarchy")
+	}
+	restored := &ContractionHierarchyOf[N, W]{nodes: res.Nodes, rank: res.Rank, up: res.Up, down: res.Down}
+	if !restored.valid() {
+		return errors.New("corrupt contraction hierarchy")
+	}
+	*ch = *restored
+	ch.index()
+	return nil
+}
+
+// valid checks the arcs of a decoded hierarchy, so that corrupt data fails to load instead of
+// panicking in a query. Every arc must lead up in rank, and every shortcut must skip a node
+// of lower rank than both ends through two arcs of the hierarchy, so unpacking terminates.
+func (ch *ContractionHierarchyOf[N, W]) valid() bool {
+	n := len(ch.nodes)
+	// u -> v is an arc of the hierarchy with rank(u) < rank(v) or the other way round
+	arcValid := func(u, v int, a chArc[W]) bool {
+		if a.To < 0 || a.To >= n {
+			return false
+		}
+		if a.Via == -1 {
+			return true
+		}
+		if a.Via < 0 || a.Via >= n || ch.rank[a.Via] >= ch.rank[u] || ch.rank[a.Via] >= ch.rank[v] {
+			return false
+		}
+		_, ok := ch.findArc(u, a.Via)
+		_, ok2 := ch.findArc(a.Via, v)
+		return ok && ok2
+	}
+	for u := 0; u < n; u++ {
+		for _, a := range ch.up[u] {
+			if !arcValid(u, a.To, a) || ch.rank[a.To] <= ch.rank[u] {
+				return false
+			}
+		}
+		for _, a := range ch.down[u] {
+			if !arcValid(a.To, u, a) || ch.rank[a.To] <= ch.rank[u] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CHQueryOf answers shortest path queries on a contraction hierarchy. It keeps its buffers
+// between queries, so it must not be used by several goroutines at once; create one per goroutine.
+type CHQueryOf[N comparable, W Number] struct {
+	ch      *ContractionHierarchyOf[N, W]
+	fw, bw  *chSearch[W]
+	settled int
+}
+
+type CHQuery = CHQueryOf[int, float64]
+
+// Settled returns the number of nodes settled by the last query
+func (q *CHQueryOf[N, W]) Settled() int {
+	return q.settled
+}
+
+// chSearch is one direction of a query
+type chSearch[W Number] struct {
+	arcs    [][]chArc[W]
+	inf     W // infinity is computed once, not for every touched node of every query
+	dist    []W
+	pre     []int
+	touched []int
+	mq      *pq.DAry[W]
+}
+
+func newCHSearch[W Number](arcs [][]chArc[W]) *chSearch[W] {
+	s := &chSearch[W]{
+		arcs: arcs,
+		inf:  infinity[W](),
+		dist: make([]W, len(arcs)),
+		pre:  make([]int, len(arcs)),
+		mq:   pq.NewMin[W](len(arcs)),
+	}
+	for i := range s.dist {
+		s.dist[i] = s.inf
+		s.pre[i] = -1
+	}
+	return s
+}
+
+func (s *chSearch[W]) reset(source int) {
+	for _, v := range s.touched {
+		s.dist[v] = s.inf
+		s.pre[v] = -1
+	}
+	for s.mq.Len() > 0 {
+		s.mq.Pop()
+	}
+	s.touched = append(s.touched[:0], source)
+	s.dist[source] = 0
+	s.mq.Push(source, 0)
+}
+
+func (ch *ContractionHierarchyOf[N, W]) NewQuery() *CHQueryOf[N, W] {
+	return &CHQueryOf[N, W]{ch: ch, fw: newCHSearch(ch.up), bw: newCHSearch(ch.down)}
+}
+
+// ShortestPath returns the nodes on the shortest path from start to goal and its cost
+func (q *CHQueryOf[N, W]) ShortestPath(start, goal N) ([]N, W, error) {
+	ch := q.ch
+	s, ok := ch.pos[start]
+	if !ok {
+		return nil, infinity[W](), errors.New("start node is not part of the graph")
+	}
+	t, ok := ch.pos[goal]
+	if !ok {
+		return nil, infinity[W](), errors.New("goal node is not part of the graph")
+	}
+
+	inf := q.fw.inf
+	q.fw.reset(s)
+	q.bw.reset(t)
+	q.settled = 0
+	best, meet := inf, -1
+	for q.fw.mq.Len() > 0 || q.bw.mq.Len() > 0 {
+		side, other := q.fw, q.bw
+		if side.mq.Len() == 0 {
+			side, other = q.bw, q.fw
+		} else if other.mq.Len() > 0 {
+			_, fMin := side.mq.Peek()
+			if _, bMin := other.mq.Peek(); bMin < fMin {
+				side, other = q.bw, q.fw
+			}
+		}
+
+		u, dist := side.mq.Pop()
+		if best != inf && dist >= best {
+			// nothing in this direction can lead to a shorter path anymore
+			for side.mq.Len() > 0 {
+				side.mq.Pop()
+			}
+			continue
+		}
+		q.settled++
+		if other.dist[u] != inf && dist+other.dist[u] < best {
+			best, meet = dist+other.dist[u], u
+		}
+		for _, a := range side.arcs[u] {
+			if alt := dist + a.Weight; alt < side.dist[a.To] {
+				if side.dist[a.To] == inf {
+					side.touched = append(side.touched, a.To)
+				}
+				side.dist[a.To] = alt
+				side.pre[a.To] = u
+				side.mq.Push(a.To, alt)
+			}
+		}
+	}
+
+	if meet == -1 {
+		return nil, inf, ErrNoPath
+	}
+
+	// the upward path from start to meet and the downward path from meet to goal
+	up := make([]int, 0)
+	for v := meet; v != -1; v = q.fw.pre[v] {
+		up = append(up, v)
+	}
+	hops := make([]int, 0, len(up))
+	for i := len(up) - 1; i >= 0; i-- {
+		hops = append(hops, up[i])
+	}
+	for v := q.bw.pre[meet]; v != -1; v = q.bw.pre[v] {
+		hops = append(hops, v)
+	}
+
+	// replace the shortcuts by the paths they stand for, the cost is summed in path order
+	// so that it is exactly what Dijkstra computes for the same path
+	path := []N{ch.nodes[s]}
+	var cost W
+	for i := 1; i < len(hops); i++ {
+		ch.unpack(hops[i-1], hops[i], &path, &cost)
+	}
+	return path, cost, nil
+}
+
+// unpack appends the nodes after u on the arc u -> v to path
+func (ch *ContractionHierarchyOf[N, W]) unpack(u, v int, path *[]N, cost *W) {
+	a := ch.arc(u, v)
+	if a.Via == -1 {
+		*path = append(*path, ch.nodes[v])
+		*cost += a.Weight
+		return
+	}
+	ch.unpack(u, a.Via, path, cost)
+	ch.unpack(a.Via, v, path, cost)
+}
+
+// arc returns the arc u -> v of the hierarchy
+func (ch *ContractionHierarchyOf[N, W]) arc(u, v int) chArc[W] {
+	a, ok := ch.findArc(u, v)
+	if !ok {
+		panic("graph: missing arc in contraction hierarchy")
+	}
+	return a
+}
+
+func (ch *ContractionHierarchyOf[N, W]) findArc(u, v int) (chArc[W], bool) {
+	if ch.rank[v] > ch.rank[u] {
+		for _, a := range ch.up[u] {
+			if a.To == v {
+				return a, true
+			}
+		}
+	}
+	for _, a := range ch.down[v] {
+		if a.To == u {
+			return chArc[W]{v, a.Weight, a.Via}, true
+		}
+	}
+	return chArc[W]{}, false
+}
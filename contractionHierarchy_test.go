@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Example Graph:
+// ┌─────┐     1     ┌─────┐     1     ┌─────┐
+// │  0  ├──────────►│  1  ├──────────►│  2  │
+// └──┬──┘           └─────┘           └──┬──┘
+// .  │                                   │
+// .  │5                                 1│
+// .  │                                   ▼
+// .  │              ┌─────┐     1     ┌─────┐
+// .  └─────────────►│  4  │◄──────────┤  3  │
+// .                 └─────┘           └─────┘
+func TestContractionHierarchy(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(0, 4, 5)
+
+	ch, err := g.ContractionHierarchy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, cost, err := ch.ShortestPath(0, 4)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(path, []int{0, 1, 2, 3, 4}) || cost != 4 {
+		t.Errorf("expected [0 1 2 3 4] with cost 4, got %v with cost %v", path, cost)
+	}
+	if _, _, err := ch.ShortestPath(4, 0); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected no path from 4 to 0, got %v", err)
+	}
+	if path, cost, _ := ch.ShortestPath(2, 2); !reflect.DeepEqual(path, []int{2}) || cost != 0 {
+		t.Errorf("expected the empty path [2], got %v with cost %v", path, cost)
+	}
+	if _, _, err := ch.ShortestPath(0, 9); err == nil {
+		t.Errorf("expected an error for an unknown node")
+	}
+}
+
+func TestContractionHierarchyNegativeWeights(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -1)
+	if _, err := g.ContractionHierarchy(); err == nil {
+		t.Errorf("expected an error for negative weights")
+	}
+}
+
+func TestContractionHierarchyMatchesDijkstra(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := randomGraph(300, 1200, 11, undirected, 1000000)
+		ch, err := g.ContractionHierarchy()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := ch.NewQuery()
+		for s := 0; s < 300; s += 13 {
+			tree, err := g.Dijkstra(s)
+			if err != nil {
+				t.Error(err)
+			}
+			for goal := 0; goal < 300; goal += 7 {
+				expect, expectCost, reachable := tree.PathTo(goal)
+				path, cost, err := q.ShortestPath(s, goal)
+				if !reachable {
+					if !errors.Is(err, ErrNoPath) {
+						t.Errorf("expected no path from %d to %d, got %v", s, goal, path)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(path, expect) || cost != expectCost {
+					t.Errorf("from %d to %d expected %v with cost %v, got %v with cost %v", s, goal, expect, expectCost, path, cost)
+				}
+			}
+		}
+	}
+}
+
+func TestContractionHierarchyMarshal(t *testing.T) {
+	g := randomGraph(100, 400, 3, false, 1000000)
+	ch, err := g.ContractionHierarchy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ch.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored ContractionHierarchy
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.NumShortcuts() != ch.NumShortcuts() {
+		t.Errorf("expected %d shortcuts, got %d", ch.NumShortcuts(), restored.NumShortcuts())
+	}
+	for goal := 0; goal < 100; goal++ {
+		expect, expectCost, expectErr := ch.ShortestPath(0, goal)
+		path, cost, err := restored.ShortestPath(0, goal)
+		if err != expectErr || !reflect.DeepEqual(path, expect) || cost != expectCost {
+			t.Errorf("expected %v with cost %v to %d, got %v with cost %v", expect, expectCost, goal, path, cost)
+		}
+	}
+
+	if err := restored.UnmarshalBinary(data[:len(data)/2]); err == nil {
+		t.Errorf("expected an error for truncated data")
+	}
+}
+
+func TestContractionHierarchyUnmarshalCorrupt(t *testing.T) {
+	// 0 -> 1 -> 2 with 1 contracted first and a shortcut 0 -> 2 that skips it
+	valid := chData[int, float64]{
+		Nodes: []int{0, 1, 2},
+		Rank:  []int{1, 0, 2},
+		Up:    [][]chArc[float64]{{{2, 2, 1}}, {{2, 1, -1}}, nil},
+		Down:  [][]chArc[float64]{nil, {{0, 1, -1}}, nil},
+	}
+	corrupt := map[string]func(d *chData[int, float64]){
+		"target out of range":  func(d *chData[int, float64]) { d.Up[1][0].To = 7 },
+		"via out of range":     func(d *chData[int, float64]) { d.Up[0][0].Via = -5 },
+		"arc leading down":     func(d *chData[int, float64]) { d.Rank = []int{1, 2, 0} },
+		"missing arc of via":   func(d *chData[int, float64]) { d.Down[1] = nil },
+		"source out of range":  func(d *chData[int, float64]) { d.Down[1][0].To = 3 },
+		"via of a higher rank": func(d *chData[int, float64]) { d.Rank = []int{0, 1, 2} },
+	}
+
+	encode := func(d chData[int, float64]) []byte {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+	var ch ContractionHierarchy
+	if err := ch.UnmarshalBinary(encode(valid)); err != nil {
+		t.Fatalf("expected the valid hierarchy to load, got %v", err)
+	}
+	if path, cost, err := ch.ShortestPath(0, 2); err != nil || !reflect.DeepEqual(path, []int{0, 1, 2}) || cost != 2 {
+		t.Errorf("expected [0 1 2] with cost 2, got %v with cost %v (%v)", path, cost, err)
+	}
+
+	for name, change := range corrupt {
+		var d chData[int, float64]
+		// a deep copy of the valid data
+		if err := gob.NewDecoder(bytes.NewReader(encode(valid))).Decode(&d); err != nil {
+			t.Fatal(err)
+		}
+		change(&d)
+		var restored ContractionHierarchy
+		if err := restored.UnmarshalBinary(encode(d)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func BenchmarkContractionHierarchyBuild(b *testing.B) {
+	g := randomGrid(50, 50, 1).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.ContractionHierarchy()
+	}
+}
+
+func BenchmarkContractionHierarchyQuery(b *testing.B) {
+	ch, err := randomGrid(100, 100, 1).ContractionHierarchy()
+	if err != nil {
+		b.Fatal(err)
+	}
+	q := ch.NewQuery()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.ShortestPath(i%10000, (i*7919)%10000)
+	}
+}
+
+func BenchmarkContractionHierarchyBidirectionalDijkstra(b *testing.B) {
+	g := randomGrid(100, 100, 1).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.BidirectionalDijkstra(i%10000, (i*7919)%10000)
+	}
+}
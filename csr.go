@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"math/rand"
+
+	"github.com/timHau/graph/pq"
+)
+
+// CSROf is an immutable graph in compressed sparse row format.
+// Nodes are numbered 0..n-1 in the order of Nodes(), the arcs leaving node i are
+// targets[offsets[i]:offsets[i+1]] with the weights at the same positions.
+// Unlike the map based GraphOf, iteration order is always deterministic.
+type CSROf[N comparable, W Number] struct {
+	ids        []N
+	pos        map[N]int
+	offsets    []int
+	targets    []int
+	weights    []W
+	numEdges   int
+	undirected bool
+}
+
+type CSR = CSROf[int, float64]
+
+// Freeze returns an immutable CSR snapshot of the graph.
+// Later changes to the graph do not affect the snapshot.
+func (g *GraphOf[N, W]) Freeze() *CSROf[N, W] {
+	idx := g.denseIndex()
+	c := &CSROf[N, W]{
+		ids:        idx.ids,
+		pos:        idx.pos,
+		offsets:    make([]int, idx.len()+1),
+		undirected: g.undirected,
+	}
+	for i, v := range idx.ids {
+		for _, e := range g.AdjacencyList[v] {
+			c.targets = append(c.targets, idx.pos[e.To])
+			c.weights = append(c.weights, e.Weight)
+			if !g.undirected || i <= idx.pos[e.To] {
+				c.numEdges++
+			}
+		}
+		c.offsets[i+1] = len(c.targets)
+	}
+	return c
+}
+
+// Nodes returns all nodes in the order of their dense index
+func (c *CSROf[N, W]) Nodes() []N {
+	nodes := make([]N, len(c.ids))
+	copy(nodes, c.ids)
+	return nodes
+}
+
+func (c *CSROf[N, W]) NumNodes() int {
+	return len(c.ids)
+}
+
+func (c *CSROf[N, W]) NumEdges() int {
+	return c.numEdges
+}
+
+func (c *CSROf[N, W]) HasNode(v N) bool {
+	_, ok := c.pos[v]
+	return ok
+}
+
+func (c *CSROf[N, W]) IsDirected() bool {
+	return !c.undirected
+}
+
+// Neighbors returns the arcs leaving v
+func (c *CSROf[N, W]) Neighbors(v N) []WeightTupleOf[N, W] {
+	i, ok := c.pos[v]
+	if !ok {
+		return nil
+	}
+	targets, weights := c.arcs(i)
+	adj := make([]WeightTupleOf[N, W], len(targets))
+	for j, t := range targets {
+		adj[j] = WeightTupleOf[N, W]{c.ids[t], weights[j]}
+	}
+	return adj
+}
+
+// Weight returns the weight of the arc from u to v, or false if there is none.
+// Of several parallel arcs the lightest one is returned.
+func (c *CSROf[N, W]) Weight(u, v N) (W, bool) {
+	var weight W
+	i, ok := c.pos[u]
+	j, ok2 := c.pos[v]
+	if !ok || !ok2 {
+		return weight, false
+	}
+	found := false
+	targets, weights := c.arcs(i)
+	for k, to := range targets {
+		if to == j && (!found || weights[k] < weight) {
+			weight, found = weights[k], true
+		}
+	}
+	return weight, found
+}
+
+func (c *CSROf[N, W]) HasEdge(u, v N) bool {
+	_, ok := c.Weight(u, v)
+	return ok
+}
+
+// the CSR is its own dense view
+
+func (c *CSROf[N, W]) len() int {
+	return len(c.ids)
+}
+
+func (c *CSROf[N, W]) node(i int) N {
+	return c.ids[i]
+}
+
+func (c *CSROf[N, W]) index(v N) (int, bool) {
+	i, ok := c.pos[v]
+	return i, ok
+}
+
+func (c *CSROf[N, W]) arcs(i int) ([]int, []W) {
+	start, end := c.offsets[i], c.offsets[i+1]
+	return c.targets[start:end:end], c.weights[start:end:end]
+}
+
+// The algorithms are also available as methods, like on GraphOf
+
+func (c *CSROf[N, W]) BFS(start N, fn func(N)) {
+	BFS[N, W](c, start, fn)
+}
+
+func (c *CSROf[N, W]) DFS(start N, fn func(N)) {
+	DFS[N, W](c, start, fn)
+}
+
+func (c *CSROf[N, W]) Dijkstra(start N) (*ShortestPathTreeOf[N, W], error) {
+	return Dijkstra[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) DijkstraWithQueue(start N, newQueue func(n int) pq.Queue[W]) (*ShortestPathTreeOf[N, W], error) {
+	return DijkstraWithQueue[N, W](c, start, newQueue)
+}
+
+func (c *CSROf[N, W]) MultiSourceDijkstra(sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return MultiSourceDijkstra[N, W](c, sources, targets)
+}
+
+func (c *CSROf[N, W]) MultiSourceDijkstraTo(sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return MultiSourceDijkstraTo[N, W](c, sources, targets)
+}
+
+func (c *CSROf[N, W]) AStar(start, goal N, h func(N) W) ([]N, W, error) {
+	return AStar[N, W](c, start, goal, h)
+}
+
+func (c *CSROf[N, W]) AStarWithQueue(start, goal N, h func(N) W, newQueue func(n int) pq.Queue[W]) ([]N, W, error) {
+	return AStarWithQueue[N, W](c, start, goal, h, newQueue)
+}
+
+func (c *CSROf[N, W]) BidirectionalDijkstra(start, goal N) ([]N, W, error) {
+	return BidirectionalDijkstra[N, W](c, start, goal)
+}
+
+func (c *CSROf[N, W]) FarthestLandmarks(k int) (*LandmarksOf[N, W], error) {
+	return FarthestLandmarks[N, W](c, k)
+}
+
+func (c *CSROf[N, W]) RandomLandmarks(k int, rnd *rand.Rand) (*LandmarksOf[N, W], error) {
+	return RandomLandmarks[N, W](c, k, rnd)
+}
+
+func (c *CSROf[N, W]) KShortestPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return KShortestPaths[N, W](c, start, goal, k)
+}
+
+func (c *CSROf[N, W]) EdgeDisjointPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return EdgeDisjointPaths[N, W](c, start, goal, k)
+}
+
+func (c *CSROf[N, W]) NodeDisjointPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return NodeDisjointPaths[N, W](c, start, goal, k)
+}
+
+func (c *CSROf[N, W]) BellmanFord(start N) (*ShortestPathTreeOf[N, W], error) {
+	return BellmanFord[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) SPFA(start N) (*ShortestPathTreeOf[N, W], error) {
+	return SPFA[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) Johnson() (*JohnsonPathsOf[N, W], error) {
+	return Johnson[N, W](c)
+}
+
+func (c *CSROf[N, W]) DAGShortestPaths(start N) (*ShortestPathTreeOf[N, W], error) {
+	return DAGShortestPaths[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) LongestPath() (PathOf[N, W], error) {
+	return LongestPath[N, W](c)
+}
+
+func (c *CSROf[N, W]) CriticalPath() (*ScheduleOf[N, W], error) {
+	return CriticalPath[N, W](c)
+}
+
+func (c *CSROf[N, W]) FloydWarshall() *AllPairsOf[N, W] {
+	return FloydWarshall[N, W](c)
+}
+
+func (c *CSROf[N, W]) Kosaraju() [][]N {
+	return Kosaraju[N, W](c)
+}
+
+func (c *CSROf[N, W]) HasCycle() bool {
+	return HasCycle[N, W](c)
+}
+
+func (c *CSROf[N, W]) TopologicalSort() ([]N, error) {
+	return TopologicalSort[N, W](c)
+}
+
+func (c *CSROf[N, W]) WidestPath(start N) (*BottleneckTreeOf[N, W], error) {
+	return WidestPath[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) MinimaxPath(start N) (*BottleneckTreeOf[N, W], error) {
+	return MinimaxPath[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) WidestPathMST(start N) (*BottleneckTreeOf[N, W], error) {
+	return WidestPathMST[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) MinimaxPathMST(start N) (*BottleneckTreeOf[N, W], error) {
+	return MinimaxPathMST[N, W](c, start)
+}
+
+func (c *CSROf[N, W]) Prim() (*GraphOf[N, W], error) {
+	return Prim[N, W](c)
+}
+
+func (c *CSROf[N, W]) PrimWithQueue(newQueue func(n int) pq.Queue[W]) (*GraphOf[N, W], error) {
+	return PrimWithQueue[N, W](c, newQueue)
+}
+
+func (c *CSROf[N, W]) Laplacian() []float64 {
+	return Laplacian[N, W](c)
+}
+
+func (c *CSROf[N, W]) HasHamiltonianPathDP() bool {
+	return HasHamiltonianPathDP[N, W](c)
+}
+
+func (c *CSROf[N, W]) PageRank(damping float64, iterations int) map[N]float64 {
+	return PageRank[N, W](c, damping, iterations)
+}
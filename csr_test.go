@@ -0,0 +1,178 @@
+package graph
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 1)
+	g.AddEdge(3, 7000, 2)
+	g.AddEdge(7000, 100, 3)
+	g.AddNode(42)
+
+	c := g.Freeze()
+	if !reflect.DeepEqual(c.Nodes(), []int{3, 42, 100, 7000}) {
+		t.Errorf("expected nodes [3 42 100 7000], got %v", c.Nodes())
+	}
+	if c.NumNodes() != 4 || c.NumEdges() != 3 {
+		t.Errorf("expected 4 nodes and 3 edges, got %d and %d", c.NumNodes(), c.NumEdges())
+	}
+	if !reflect.DeepEqual(c.offsets, []int{0, 2, 2, 2, 3}) {
+		t.Errorf("expected offsets [0 2 2 2 3], got %v", c.offsets)
+	}
+	if !reflect.DeepEqual(c.targets, []int{2, 3, 2}) {
+		t.Errorf("expected targets [2 3 2], got %v", c.targets)
+	}
+	if !reflect.DeepEqual(c.weights, []float64{1, 2, 3}) {
+		t.Errorf("expected weights [1 2 3], got %v", c.weights)
+	}
+
+	expected := []WeightTuple{{100, 1}, {7000, 2}}
+	if !reflect.DeepEqual(c.Neighbors(3), expected) {
+		t.Errorf("expected neighbors %v, got %v", expected, c.Neighbors(3))
+	}
+
+	// the snapshot is not affected by later changes
+	g.AddEdge(42, 3, 1)
+	g.RemoveNode(100)
+	if c.NumNodes() != 4 || c.NumEdges() != 3 {
+		t.Errorf("expected the snapshot to be immutable, got %d nodes and %d edges", c.NumNodes(), c.NumEdges())
+	}
+}
+
+func TestFreezeUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+
+	c := g.Freeze()
+	if c.IsDirected() {
+		t.Errorf("expected an undirected snapshot")
+	}
+	if c.NumEdges() != 2 {
+		t.Errorf("expected 2 edges, got %d", c.NumEdges())
+	}
+}
+
+func TestCSRAlgorithms(t *testing.T) {
+	g := randomGraph(200, 1000, 1, false, 100)
+	c := g.Freeze()
+
+	var mapOrder, csrOrder []int
+	g.BFS(0, func(n int) { mapOrder = append(mapOrder, n) })
+	c.BFS(0, func(n int) { csrOrder = append(csrOrder, n) })
+	if !reflect.DeepEqual(mapOrder, csrOrder) {
+		t.Errorf("expected the same BFS order, got %v and %v", mapOrder, csrOrder)
+	}
+
+	mapOrder, csrOrder = nil, nil
+	g.DFS(0, func(n int) { mapOrder = append(mapOrder, n) })
+	c.DFS(0, func(n int) { csrOrder = append(csrOrder, n) })
+	if !reflect.DeepEqual(mapOrder, csrOrder) {
+		t.Errorf("expected the same DFS order, got %v and %v", mapOrder, csrOrder)
+	}
+
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	mapDist := tree.Distances()
+	tree, err = c.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	csrDist := tree.Distances()
+	if !reflect.DeepEqual(mapDist, csrDist) {
+		t.Errorf("expected the same distances")
+	}
+
+	if !reflect.DeepEqual(g.PageRank(0.85, 20), c.PageRank(0.85, 20)) {
+		t.Errorf("expected the same page rank")
+	}
+}
+
+// randomGraph creates a graph with n nodes and m random edges with integer weights 1..maxWeight.
+// With a large maxWeight shortest paths are unique with high probability, and their costs are
+// exact no matter in which order they are added.
+func randomGraph(n, m int, seed int64, undirected bool, maxWeight int) *Graph {
+	rnd := rand.New(rand.NewSource(seed))
+	g := NewGraph()
+	if undirected {
+		g = NewUndirectedGraph()
+	}
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < m; i++ {
+		g.AddEdge(rnd.Intn(n), rnd.Intn(n), float64(rnd.Intn(maxWeight)+1))
+	}
+	return g
+}
+
+// randomGrid creates an undirected rows x cols grid with random weights, a rough model of a road network
+func randomGrid(rows, cols int, seed int64) *Graph {
+	rnd := rand.New(rand.NewSource(seed))
+	g := NewUndirectedGraph()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				g.AddEdge(r*cols+c, r*cols+c+1, float64(rnd.Intn(1000)+1000))
+			}
+			if r+1 < rows {
+				g.AddEdge(r*cols+c, (r+1)*cols+c, float64(rnd.Intn(1000)+1000))
+			}
+		}
+	}
+	return g
+}
+
+func BenchmarkBFSMap(b *testing.B) {
+	g := randomGraph(10000, 50000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.BFS(0, func(int) {})
+	}
+}
+
+func BenchmarkBFSCSR(b *testing.B) {
+	c := randomGraph(10000, 50000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.BFS(0, func(int) {})
+	}
+}
+
+func BenchmarkDijkstraMap(b *testing.B) {
+	g := randomGraph(2000, 10000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Dijkstra(0)
+	}
+}
+
+func BenchmarkDijkstraCSR(b *testing.B) {
+	c := randomGraph(2000, 10000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Dijkstra(0)
+	}
+}
+
+func BenchmarkPageRankMap(b *testing.B) {
+	g := randomGraph(10000, 50000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.PageRank(0.85, 10)
+	}
+}
+
+func BenchmarkPageRankCSR(b *testing.B) {
+	c := randomGraph(10000, 50000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.PageRank(0.85, 10)
+	}
+}
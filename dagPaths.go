@@ -0,0 +1,172 @@
+package graph
+
+import "errors"
+
+// DAGShortestPaths computes the shortest distances from start in a directed acyclic graph.
+// Relaxing the arcs of every node in topological order settles each node once,
+// so unlike Dijkstra negative weights are fine, and it is faster than Bellman-Ford.
+//
+// Time Complexity: O(V + E)
+// returns the shortest path tree rooted at start, or an error if g is not a DAG
+func DAGShortestPaths[N comparable, W Number](g Reader[N, W], start N) (*ShortestPathTreeOf[N, W], error) {
+	d, order, err := dagOrder(g)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+
+	inf := infinity[W]()
+	tree := newShortestPathTree(d, s)
+	dist, pre := tree.dist, tree.pre
+	for _, u := range order {
+		if dist[u] == inf {
+			continue
+		}
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if dist[u]+weights[j] < dist[v] {
+				dist[v] = dist[u] + weights[j]
+				pre[v] = u
+			}
+		}
+	}
+	return tree, nil
+}
+
+// ScheduleOf is the longest (critical) path of a directed acyclic graph together with the schedule it implies.
+// If nodes are tasks and an arc u -> v of weight w means v can start w after u started,
+// the earliest start of v is the longest path ending in v. The latest start that does not delay
+// the whole schedule is the length of the critical path minus the longest path leaving v.
+type ScheduleOf[N comparable, W Number] struct {
+	PathOf[N, W]
+	nodes    denseView[N, W]
+	earliest []W
+	latest   []W
+}
+
+type Schedule = ScheduleOf[int, float64]
+
+// CriticalPath finds the longest path of a directed acyclic graph and the slack of every node.
+// Paths may start at any node, negative weights are allowed.
+//
+// Time Complexity: O(V + E)
+// returns an error if g is not a DAG
+func CriticalPath[N comparable, W Number](g Reader[N, W]) (*ScheduleOf[N, W], error) {
+	d, order, err := dagOrder(g)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes := d.len()
+	c := &ScheduleOf[N, W]{
+		nodes:    d,
+		earliest: make([]W, numNodes),
+		latest:   make([]W, numNodes),
+	}
+	if numNodes == 0 {
+		c.Nodes = []N{}
+		return c, nil
+	}
+
+	// longest path ending in every node, every node may be the first one
+	pre := make([]int, numNodes)
+	for i := range pre {
+		pre[i] = -1
+	}
+	for _, u := range order {
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if c.earliest[u]+weights[j] > c.earliest[v] {
+				c.earliest[v] = c.earliest[u] + weights[j]
+				pre[v] = u
+			}
+		}
+	}
+
+	end := order[0]
+	for _, v := range order {
+		if c.earliest[v] > c.earliest[end] {
+			end = v
+		}
+	}
+	c.Cost = c.earliest[end]
+	for v := end; v != -1; v = pre[v] {
+		c.Nodes = append(c.Nodes, d.node(v))
+	}
+	for l, r := 0, len(c.Nodes)-1; l < r; l, r = l+1, r-1 {
+		c.Nodes[l], c.Nodes[r] = c.Nodes[r], c.Nodes[l]
+	}
+
+	// longest path leaving every node, in reverse topological order
+	tail := make([]W, numNodes)
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if weights[j]+tail[v] > tail[u] {
+				tail[u] = weights[j] + tail[v]
+			}
+		}
+		c.latest[u] = c.Cost - tail[u]
+	}
+	return c, nil
+}
+
+// LongestPath returns the longest path of a directed acyclic graph
+//
+// Time Complexity: O(V + E)
+func LongestPath[N comparable, W Number](g Reader[N, W]) (PathOf[N, W], error) {
+	c, err := CriticalPath(g)
+	if err != nil {
+		return PathOf[N, W]{}, err
+	}
+	return c.PathOf, nil
+}
+
+// Earliest returns the earliest start of v
+func (c *ScheduleOf[N, W]) Earliest(v N) W {
+	i, ok := c.nodes.index(v)
+	if !ok {
+		return 0
+	}
+	return c.earliest[i]
+}
+
+// Latest returns the latest start of v that does not make the critical path longer
+func (c *ScheduleOf[N, W]) Latest(v N) W {
+	i, ok := c.nodes.index(v)
+	if !ok {
+		return 0
+	}
+	return c.latest[i]
+}
+
+// Slack returns how much v can be delayed without making the critical path longer.
+// Nodes on the critical path have no slack.
+func (c *ScheduleOf[N, W]) Slack(v N) W {
+	return c.Latest(v) - c.Earliest(v)
+}
+
+// Slacks returns the slack of every node
+func (c *ScheduleOf[N, W]) Slacks() map[N]W {
+	res := make(map[N]W, len(c.earliest))
+	for i := range c.earliest {
+		res[c.nodes.node(i)] = c.latest[i] - c.earliest[i]
+	}
+	return res
+}
+
+func (g *GraphOf[N, W]) DAGShortestPaths(start N) (*ShortestPathTreeOf[N, W], error) {
+	return DAGShortestPaths[N, W](g, start)
+}
+
+func (g *GraphOf[N, W]) LongestPath() (PathOf[N, W], error) {
+	return LongestPath[N, W](g)
+}
+
+func (g *GraphOf[N, W]) CriticalPath() (*ScheduleOf[N, W], error) {
+	return CriticalPath[N, W](g)
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDAGShortestPaths(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -2)
+	g.AddEdge(0, 2, 3)
+	g.AddEdge(1, 2, 4)
+	g.AddEdge(2, 3, -5)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(4, 0, 1)
+
+	tree, err := g.DAGShortestPaths(0)
+	if err != nil {
+		t.Error(err)
+	}
+	bf, err := g.BellmanFord(0)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(tree.Distances(), bf.Distances()) {
+		t.Errorf("expected %v, got %v", bf.Distances(), tree.Distances())
+	}
+	path, cost, ok := tree.PathTo(3)
+	if !ok || !reflect.DeepEqual(path, []int{0, 1, 2, 3}) || cost != -3 {
+		t.Errorf("expected [0 1 2 3] with cost -3, got %v with cost %v", path, cost)
+	}
+	if tree.Reachable(4) {
+		t.Errorf("expected 4 to be unreachable")
+	}
+
+	g.AddEdge(3, 0, 1)
+	if _, err := g.DAGShortestPaths(0); err == nil {
+		t.Errorf("expected an error for a graph with a cycle")
+	}
+}
+
+// Example Graph: a build pipeline, arcs are labeled with the duration of their source task
+// .             3   ┌─────┐   4
+// .         ┌──────►│  1  ├───────┐
+// .         │       └─────┘       ▼
+// .      ┌──┴──┐               ┌─────┐   2    ┌─────┐
+// .      │  0  │               │  3  ├───────►│  4  │
+// .      └──┬──┘               └─────┘        └─────┘
+// .         │   2   ┌─────┐   1   ▲              ▲
+// .         └──────►│  2  ├───────┘              │
+// .                 └──┬──┘             1        │
+// .                    └─────────────────────────┘
+func TestCriticalPath(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(1, 3, 4)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 2)
+	g.AddEdge(2, 4, 1)
+
+	c, err := g.CriticalPath()
+	if err != nil {
+		t.Error(err)
+	}
+	expect := PathOf[int, int]{[]int{0, 1, 3, 4}, 9}
+	if !reflect.DeepEqual(c.PathOf, expect) {
+		t.Errorf("expected %v, got %v", expect, c.PathOf)
+	}
+	expectSlack := map[int]int{0: 0, 1: 0, 2: 4, 3: 0, 4: 0}
+	if !reflect.DeepEqual(c.Slacks(), expectSlack) {
+		t.Errorf("expected %v, got %v", expectSlack, c.Slacks())
+	}
+	if c.Earliest(2) != 2 || c.Latest(2) != 6 || c.Slack(2) != 4 {
+		t.Errorf("expected node 2 to start between 2 and 6, got %d and %d", c.Earliest(2), c.Latest(2))
+	}
+
+	longest, err := g.LongestPath()
+	if err != nil || !reflect.DeepEqual(longest, expect) {
+		t.Errorf("expected %v, got %v", expect, longest)
+	}
+}
+
+func TestLongestPathNegativeWeights(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -1)
+	g.AddEdge(1, 2, 5)
+	g.AddEdge(0, 2, 3)
+
+	longest, err := g.LongestPath()
+	if err != nil {
+		t.Error(err)
+	}
+	expect := Path{[]int{1, 2}, 5}
+	if !reflect.DeepEqual(longest, expect) {
+		t.Errorf("expected %v, got %v", expect, longest)
+	}
+
+	if _, err := NewUndirectedGraph().LongestPath(); err == nil {
+		t.Errorf("expected an error for an undirected graph")
+	}
+}
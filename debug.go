@@ -0,0 +1,7 @@
+//go:build debug
+
+package graph
+
+func init() {
+	debug = true
+}
@@ -6,25 +6,41 @@ package graph
 //
 // Time Complexity: O(V + E)
 // Space Complexity: O(V)
-func (g *Graph) DFS(start int, fn func(int)) {
-	numNodes := g.NumNodes()
-	visited := make([]bool, numNodes)
-	g.DFSstep(start, visited, fn)
+func DFS[N comparable, W Number](g Reader[N, W], start N, fn func(N)) {
+	d := viewOf(g)
+	visited := make([]bool, d.len())
+	if s, ok := d.index(start); ok {
+		dfsStep(d, s, visited, fn)
+	}
 
 	// If the graph is not connected, we will start exploring the remaining graph components
-	for i := 0; i < numNodes; i++ {
+	for i := range visited {
 		if !visited[i] {
-			g.DFSstep(i, visited, fn)
+			dfsStep(d, i, visited, fn)
 		}
 	}
 }
 
-func (g *Graph) DFSstep(start int, visited []bool, fn func(int)) {
+func (g *GraphOf[N, W]) DFS(start N, fn func(N)) {
+	DFS[N, W](g, start, fn)
+}
+
+// DFSstep performs a depth first search starting at node start.
+// visited is indexed by the position of a node in g.Nodes()
+func (g *GraphOf[N, W]) DFSstep(start N, visited []bool, fn func(N)) {
+	d := viewOf[N, W](g)
+	if s, ok := d.index(start); ok {
+		dfsStep(d, s, visited, fn)
+	}
+}
+
+func dfsStep[N comparable, W Number](d denseView[N, W], start int, visited []bool, fn func(N)) {
 	visited[start] = true
-	fn(start)
-	for _, edge := range g.AdjEdges(start) {
-		if !visited[edge.To] {
-			g.DFSstep(edge.To, visited, fn)
+	fn(d.node(start))
+	targets, _ := d.arcs(start)
+	for _, to := range targets {
+		if !visited[to] {
+			dfsStep(d, to, visited, fn)
 		}
 	}
 }
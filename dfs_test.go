@@ -117,3 +117,19 @@ func TestDFSDisconnected(t *testing.T) {
 		t.Errorf("expected [0, 2, 3, 1], got %v", res)
 	}
 }
+
+func TestDFSSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 1)
+	g.AddEdge(100, 8, 1)
+	g.AddEdge(3, 7000, 1)
+	g.AddNode(42)
+
+	res := make([]int, 0)
+	g.DFS(3, func(n int) {
+		res = append(res, n)
+	})
+	if !reflect.DeepEqual(res, []int{3, 100, 8, 7000, 42}) {
+		t.Errorf("expected [3, 100, 8, 7000, 42], got %v", res)
+	}
+}
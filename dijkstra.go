@@ -1,73 +1,85 @@
 package graph
 
 import (
-	"container/heap"
 	"errors"
-	"math"
+
+	"github.com/timHau/graph/pq"
 )
 
 // Dijkstra's algorithm for single source shortest paths
 //
 // Given an start node, find the shortest path to all other nodes in the graph
-// Time Complexity: O(V^2)
+// Time Complexity: O((V + E) log V)
 // Space Complexity: O(V)
-// returns:
-// 1)  a list of shortest distances to all other nodes
-// 2)  a list of predecessors for each node
-func (g *Graph) Dijkstra(start int) ([]float64, []int, error) {
-	if g.HasNegativeEdges() {
-		return nil, nil, errors.New("dijkstras Algorithm does not support negative edge weights")
+// returns the shortest path tree rooted at start
+func Dijkstra[N comparable, W Number](g Reader[N, W], start N) (*ShortestPathTreeOf[N, W], error) {
+	return DijkstraWithQueue[N, W](g, start, newMinQueue[W])
+}
+
+// DijkstraWithQueue is Dijkstra with the min queue returned by newQueue for the items 0..n-1,
+// e.g. a pq.NewRadix queue for integer weights. Monotone queues are fine, since the
+// distances are popped in increasing order.
+func DijkstraWithQueue[N comparable, W Number](g Reader[N, W], start N, newQueue func(n int) pq.Queue[W]) (*ShortestPathTreeOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("dijkstras Algorithm does not support negative edge weights")
 	}
 
-	nodes := g.Nodes()
-	numNodes := len(nodes)
-	// Each entry in the distance array represents the distance from the start node to the node at the index
-	distances := make([]float64, numNodes)
-	distances[start] = 0
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
 
-	// List of predecessors for each node
-	pre := make([]int, numNodes)
-	pre[start] = -1
+	return dijkstra[N, W](d, s, newQueue(d.len())), nil
+}
 
-	mq := make(MinQueue, 0)
-	mq.Push(&Item{
-		Prio:  distances[start],
-		Node:  start,
-		Index: start,
-	})
+// newMinQueue is the binary heap used when no queue is given
+func newMinQueue[W Number](n int) pq.Queue[W] {
+	return pq.NewMin[W](n)
+}
 
-	// initialize the lists and the priority queue
-	for i := range nodes {
-		if i != start {
-			distances[i] = math.MaxFloat64
-			pre[i] = -1
-			mq.Push(&Item{
-				Prio:  distances[i],
-				Node:  i,
-				Index: i,
-			})
-		}
+// dijkstra runs the search from s with the given empty min queue
+func dijkstra[N comparable, W Number](d denseView[N, W], s int, mq pq.Queue[W]) *ShortestPathTreeOf[N, W] {
+	// distances and predecessors of all nodes, initialized to infinity and -1
+	tree := newShortestPathTree(d, s)
+	dijkstraLoop[N, W](d, tree, []int{s}, mq, nil)
+	return tree
+}
+
+// dijkstraLoop runs the search from sources, which must already have distance 0 in tree.
+// settle is called with every node once its distance is final, if it returns true the search stops.
+func dijkstraLoop[N comparable, W Number](d denseView[N, W], tree *ShortestPathTreeOf[N, W], sources []int, mq pq.Queue[W], settle func(int) bool) {
+	distances, pre := tree.dist, tree.pre
+
+	// nodes are only queued once they are reached, so unreachable nodes are never touched
+	for _, s := range sources {
+		mq.Push(s, 0)
 	}
-	heap.Init(&mq)
 
 	// while the priority queue is not empty
 	for mq.Len() > 0 {
-		// get the node with the smallest distance
-		item := heap.Pop(&mq).(*Item)
-		u := item.Node
-		for _, e := range g.AdjEdges(u) {
-			v := e.To
-			vItem := mq.FindNode(v)
-			if vItem != nil {
-				alt := distances[u] + e.Weight
-				if alt < distances[v] && distances[u] != math.MaxFloat64 {
-					distances[v] = alt
-					pre[v] = item.Node
-					mq.UpdatePrio(vItem, alt)
-				}
+		// get the node with the smallest distance, its distance is final
+		u, _ := mq.Pop()
+		if settle != nil && settle(u) {
+			return
+		}
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			// settled nodes are never improved, since all weights are non negative
+			alt := distances[u] + weights[j]
+			if alt < distances[v] {
+				distances[v] = alt
+				pre[v] = u
+				mq.Push(v, alt)
 			}
 		}
 	}
+}
+
+func (g *GraphOf[N, W]) Dijkstra(start N) (*ShortestPathTreeOf[N, W], error) {
+	return Dijkstra[N, W](g, start)
+}
 
-	return distances, pre, nil
+func (g *GraphOf[N, W]) DijkstraWithQueue(start N, newQueue func(n int) pq.Queue[W]) (*ShortestPathTreeOf[N, W], error) {
+	return DijkstraWithQueue[N, W](g, start, newQueue)
 }
@@ -1,7 +1,13 @@
 package graph
 
 import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"reflect"
 	"testing"
+
+	"github.com/timHau/graph/pq"
 )
 
 // Example Graph:
@@ -48,21 +54,51 @@ func TestDijkstra(t *testing.T) {
 		5: []WeightTuple{},
 	}
 	g := FromAdjList(adjList)
-	dist, pre, err := g.Dijkstra(0)
+	tree, err := g.Dijkstra(0)
 	if err != nil {
 		t.Error(err)
 	}
+	dist, pre := tree.Distances(), tree.Predecessors()
 
-	expectDist := []float64{0, 7, 9, 16, 19, 17}
-	expectPre := []int{-1, 0, 1, 1, 2, 3}
+	expectDist := map[int]float64{0: 0, 1: 7, 2: 9, 3: 16, 4: 19, 5: 17}
+	expectPre := map[int]int{1: 0, 2: 1, 3: 1, 4: 2, 5: 3}
 
-	for i := 0; i < len(dist); i++ {
-		if dist[i] != expectDist[i] {
-			t.Errorf("expected: %v, got: %v", expectDist, dist)
-		}
-		if pre[i] != expectPre[i] {
-			t.Errorf("expected: %v, got: %v", expectPre, pre)
-		}
+	if !reflect.DeepEqual(dist, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, dist)
+	}
+	if !reflect.DeepEqual(pre, expectPre) {
+		t.Errorf("expected: %v, got: %v", expectPre, pre)
+	}
+}
+
+func TestDijkstraSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 7)
+	g.AddEdge(3, 7000, 12)
+	g.AddEdge(100, 7000, 2)
+
+	tree, err := g.Dijkstra(3)
+	if err != nil {
+		t.Error(err)
+	}
+	dist, pre := tree.Distances(), tree.Predecessors()
+
+	expectDist := map[int]float64{3: 0, 100: 7, 7000: 9}
+	expectPre := map[int]int{100: 3, 7000: 100}
+	if !reflect.DeepEqual(dist, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, dist)
+	}
+	if !reflect.DeepEqual(pre, expectPre) {
+		t.Errorf("expected: %v, got: %v", expectPre, pre)
+	}
+}
+
+func TestDijkstraUnknownStart(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	_, err := g.Dijkstra(5)
+	if err == nil {
+		t.Errorf("expected error for a start node that is not in the graph")
 	}
 }
 
@@ -76,8 +112,203 @@ func TestDijkstraNegative(t *testing.T) {
 		5: []WeightTuple{},
 	}
 	g := FromAdjList(adjList)
-	_, _, err := g.Dijkstra(0)
+	_, err := g.Dijkstra(0)
 	if err == nil {
 		t.Errorf("Dijkstra should not work with negative weights")
 	}
 }
+
+func TestDijkstraIntWeights(t *testing.T) {
+	g := FromEdgeList([]EdgeOf[string, int]{
+		{"a", "b", 7},
+		{"a", "c", 12},
+		{"b", "c", 2},
+	})
+	g.AddNode("d")
+
+	tree, err := g.Dijkstra("a")
+	if err != nil {
+		t.Error(err)
+	}
+	dist, pre := tree.Distances(), tree.Predecessors()
+
+	expectDist := map[string]int{"a": 0, "b": 7, "c": 9, "d": infinity[int]()}
+	expectPre := map[string]string{"b": "a", "c": "b"}
+	if !reflect.DeepEqual(dist, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, dist)
+	}
+	if !reflect.DeepEqual(pre, expectPre) {
+		t.Errorf("expected: %v, got: %v", expectPre, pre)
+	}
+}
+
+// linearQueue mirrors the previous MinQueue, which found nodes with a linear scan
+type linearItem struct {
+	node  int
+	prio  float64
+	index int
+}
+
+type linearQueue []*linearItem
+
+func (mq linearQueue) Len() int           { return len(mq) }
+func (mq linearQueue) Less(i, j int) bool { return mq[i].prio < mq[j].prio }
+
+func (mq linearQueue) Swap(i, j int) {
+	mq[i], mq[j] = mq[j], mq[i]
+	mq[i].index = i
+	mq[j].index = j
+}
+
+func (mq *linearQueue) Push(x any) {
+	item := x.(*linearItem)
+	item.index = len(*mq)
+	*mq = append(*mq, item)
+}
+
+func (mq *linearQueue) Pop() any {
+	old := *mq
+	item := old[len(old)-1]
+	item.index = -1
+	*mq = old[:len(old)-1]
+	return item
+}
+
+func (mq linearQueue) find(node int) *linearItem {
+	for _, item := range mq {
+		if item.node == node {
+			return item
+		}
+	}
+	return nil
+}
+
+// dijkstraLinear is the previous implementation that queues every node up front
+// and finds nodes with a linear scan, kept as a baseline for the benchmarks
+func dijkstraLinear(g *Graph, start int) map[int]float64 {
+	d := viewOf[int, float64](g)
+	s, _ := d.index(start)
+	tree := newShortestPathTree(d, s)
+	distances := tree.dist
+
+	mq := make(linearQueue, 0)
+	for i := 0; i < d.len(); i++ {
+		mq.Push(&linearItem{prio: distances[i], node: i})
+	}
+	heap.Init(&mq)
+	for mq.Len() > 0 {
+		u := heap.Pop(&mq).(*linearItem).node
+		if math.IsInf(distances[u], 1) {
+			break
+		}
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if vItem := mq.find(v); vItem != nil {
+				if alt := distances[u] + weights[j]; alt < distances[v] {
+					distances[v] = alt
+					vItem.prio = alt
+					heap.Fix(&mq, vItem.index)
+				}
+			}
+		}
+	}
+	return tree.Distances()
+}
+
+func TestDijkstraMatchesLinear(t *testing.T) {
+	g := randomGraph(500, 2500, 7, false, 100)
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(tree.Distances(), dijkstraLinear(g, 0)) {
+		t.Errorf("expected the same distances as the linear queue")
+	}
+}
+
+func BenchmarkDijkstraIndexed(b *testing.B) {
+	g := randomGraph(100000, 500000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Dijkstra(0)
+	}
+}
+
+func BenchmarkDijkstraIndexedCSR(b *testing.B) {
+	c := randomGraph(100000, 500000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Dijkstra(0)
+	}
+}
+
+// takes tens of seconds per iteration, the linear scans dominate everything else
+func BenchmarkDijkstraLinear(b *testing.B) {
+	g := randomGraph(100000, 500000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraLinear(g, 0)
+	}
+}
+
+func TestDijkstraQueues(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 2000; i++ {
+		g.AddEdge(rnd.Intn(300), rnd.Intn(300), rnd.Intn(50))
+	}
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := tree.Distances()
+
+	for name, queue := range testQueues {
+		tree, err := g.DijkstraWithQueue(0, queue)
+		if err != nil {
+			t.Error(err)
+		}
+		if dist := tree.Distances(); !reflect.DeepEqual(dist, expect) {
+			t.Errorf("%s: expected the same distances as the binary heap", name)
+		}
+	}
+}
+
+// testQueues are the queues besides the default binary heap
+var testQueues = map[string]func(n int) pq.Queue[int]{
+	"4-ary":   func(n int) pq.Queue[int] { return pq.NewDAry(n, 4, pq.Less[int]) },
+	"pairing": func(n int) pq.Queue[int] { return pq.NewPairing(n, pq.Less[int]) },
+	"radix":   func(n int) pq.Queue[int] { return pq.NewRadix[int](n) },
+}
+
+func benchmarkDijkstraQueue(b *testing.B, queue func(n int) pq.Queue[int]) {
+	g := NewGraphOf[int, int]()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		g.AddNode(i)
+	}
+	for i := 0; i < 500000; i++ {
+		g.AddEdge(rnd.Intn(100000), rnd.Intn(100000), rnd.Intn(100)+1)
+	}
+	c := g.Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstra[int, int](c, 0, queue(c.len()))
+	}
+}
+
+func BenchmarkDijkstraBinaryHeap(b *testing.B) {
+	benchmarkDijkstraQueue(b, func(n int) pq.Queue[int] { return pq.NewMin[int](n) })
+}
+
+func BenchmarkDijkstraFourAryHeap(b *testing.B) {
+	benchmarkDijkstraQueue(b, func(n int) pq.Queue[int] { return pq.NewDAry(n, 4, pq.Less[int]) })
+}
+
+func BenchmarkDijkstraPairingHeap(b *testing.B) {
+	benchmarkDijkstraQueue(b, func(n int) pq.Queue[int] { return pq.NewPairing(n, pq.Less[int]) })
+}
+
+func BenchmarkDijkstraRadixHeap(b *testing.B) {
+	benchmarkDijkstraQueue(b, func(n int) pq.Queue[int] { return pq.NewRadix[int](n) })
+}
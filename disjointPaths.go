@@ -0,0 +1,295 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/timHau/graph/pq"
+)
+
+// ErrNotEnoughPaths is returned when fewer disjoint paths exist than requested
+var ErrNotEnoughPaths = errors.New("not enough disjoint paths")
+
+// noDisjointPathsError is returned when there is no path at all, it matches both ErrNotEnoughPaths and ErrNoPath
+type noDisjointPathsError struct {
+	k int
+}
+
+func (e *noDisjointPathsError) Error() string {
+	return fmt.Sprintf("%v: none of %d paths, %v", ErrNotEnoughPaths, e.k, ErrNoPath)
+}
+
+func (e *noDisjointPathsError) Is(target error) bool {
+	return target == ErrNotEnoughPaths || target == ErrNoPath
+}
+
+// EdgeDisjointPaths finds k paths from start to goal that share no edge and have the minimum total cost.
+//
+// This is Suurballe's algorithm in the form of Bhandari: Dijkstra finds a shortest path, then the
+// arcs of the path are replaced by their transpose with negated weights and the search is repeated.
+// A later path that runs backwards over an earlier one swaps the tails of both paths. Node potentials
+// from the previous searches keep all weights non negative, so every round is a plain Dijkstra.
+//
+// Time Complexity: O(k * (V + E) log V)
+// returns the paths ordered by cost, or an error wrapping ErrNotEnoughPaths if there are fewer than k.
+// If there is no path at all the error also matches ErrNoPath.
+// On multigraphs every parallel edge is used on its own, so two paths may have the same nodes
+// and only differ in their cost.
+func EdgeDisjointPaths[N comparable, W Number](g Reader[N, W], start, goal N, k int) ([]PathOf[N, W], error) {
+	return disjointPaths(g, start, goal, k, false)
+}
+
+// NodeDisjointPaths finds k paths from start to goal that share no node apart from start and goal
+// and have the minimum total cost. Every node is split into an entry and an exit that are connected
+// by a single arc, so at most one path can pass it, then the paths are found like in EdgeDisjointPaths.
+//
+// Time Complexity: O(k * (V + E) log V)
+// returns the paths ordered by cost, or an error wrapping ErrNotEnoughPaths if there are fewer than k.
+// If there is no path at all the error also matches ErrNoPath.
+// On multigraphs every parallel edge is used on its own, so two paths may have the same nodes
+// and only differ in their cost.
+func NodeDisjointPaths[N comparable, W Number](g Reader[N, W], start, goal N, k int) ([]PathOf[N, W], error) {
+	return disjointPaths(g, start, goal, k, true)
+}
+
+func disjointPaths[N comparable, W Number](g Reader[N, W], start, goal N, k int, splitNodes bool) ([]PathOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("suurballe's algorithm does not support negative edge weights")
+	}
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+	t, ok := d.index(goal)
+	if !ok {
+		return nil, errors.New("goal node is not part of the graph")
+	}
+	if s == t {
+		return nil, errors.New("start and goal must be different nodes")
+	}
+
+	net := newResidualNetwork(d, splitNodes)
+	source, sink := net.exit(s), net.entry(t)
+	for i := 0; i < k; i++ {
+		if !net.augment(source, sink) {
+			if i == 0 {
+				return nil, &noDisjointPathsError{k}
+			}
+			return nil, fmt.Errorf("%w: only %d of %d paths from %v to %v", ErrNotEnoughPaths, i, k, start, goal)
+		}
+	}
+
+	paths := net.decompose(source, sink, k)
+	res := make([]PathOf[N, W], 0, k)
+	for _, p := range paths {
+		path := PathOf[N, W]{Nodes: make([]N, len(p.nodes)), Cost: p.cost}
+		for j, v := range p.nodes {
+			path.Nodes[j] = d.node(v)
+		}
+		res = append(res, path)
+	}
+	sort.SliceStable(res, func(a, b int) bool { return res[a].Cost < res[b].Cost })
+	return res, nil
+}
+
+// residualNetwork is the residual graph of a flow with unit capacities. Arc a and a^1 are
+// an arc of the graph and its transpose, exactly one of them can be used at any time.
+// It implements denseView with reduced weights, so Dijkstra runs on it directly.
+type residualNetwork[W Number] struct {
+	split     bool
+	numNodes  int
+	adj       [][]int // arc ids leaving every network node
+	to        []int
+	weight    []W
+	free      []bool // whether the arc can be used
+	potential []W
+	// the dense graph node every network node belongs to
+	original []int
+}
+
+// newResidualNetwork copies the arcs of d. With split nodes, node v becomes the entry 2v and the exit 2v+1.
+func newResidualNetwork[N comparable, W Number](d denseView[N, W], split bool) *residualNetwork[W] {
+	n := d.len()
+	net := &residualNetwork[W]{split: split, numNodes: n}
+	if split {
+		net.numNodes = 2 * n
+	}
+	net.adj = make([][]int, net.numNodes)
+	net.potential = make([]W, net.numNodes)
+	net.original = make([]int, net.numNodes)
+	for i := range net.original {
+		net.original[i] = i
+		if split {
+			net.original[i] = i / 2
+		}
+	}
+	for u := 0; u < n; u++ {
+		if split {
+			net.addArc(net.entry(u), net.exit(u), 0)
+		}
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if u != v {
+				net.addArc(net.exit(u), net.entry(v), weights[j])
+			}
+		}
+	}
+	return net
+}
+
+func (net *residualNetwork[W]) entry(v int) int {
+	if net.split {
+		return 2 * v
+	}
+	return v
+}
+
+func (net *residualNetwork[W]) exit(v int) int {
+	if net.split {
+		return 2*v + 1
+	}
+	return v
+}
+
+// addArc adds an arc and its transpose, which can only be used after the arc itself was used
+func (net *residualNetwork[W]) addArc(u, v int, w W) {
+	net.adj[u] = append(net.adj[u], len(net.to))
+	net.to, net.weight, net.free = append(net.to, v), append(net.weight, w), append(net.free, true)
+	net.adj[v] = append(net.adj[v], len(net.to))
+	net.to, net.weight, net.free = append(net.to, u), append(net.weight, -w), append(net.free, false)
+}
+
+func (net *residualNetwork[W]) len() int       { return net.numNodes }
+func (net *residualNetwork[W]) node(i int) int { return i }
+func (net *residualNetwork[W]) index(v int) (int, bool) {
+	return v, v >= 0 && v < net.numNodes
+}
+
+// arcs returns the usable arcs of u with reduced weights w(u, v) + potential(u) - potential(v)
+func (net *residualNetwork[W]) arcs(u int) ([]int, []W) {
+	targets := make([]int, 0, len(net.adj[u]))
+	weights := make([]W, 0, len(net.adj[u]))
+	for _, a := range net.adj[u] {
+		if net.free[a] {
+			targets = append(targets, net.to[a])
+			weights = append(weights, net.reduced(a))
+		}
+	}
+	return targets, weights
+}
+
+func (net *residualNetwork[W]) reduced(a int) W {
+	from := net.to[a^1]
+	w := net.weight[a] + net.potential[from] - net.potential[net.to[a]]
+	// rounding errors of floating point weights must not make Dijkstra see negative weights
+	if w < 0 {
+		return 0
+	}
+	return w
+}
+
+// augment sends one more unit of flow along a shortest path from source to sink.
+// It reports false if the sink can not be reached anymore.
+func (net *residualNetwork[W]) augment(source, sink int) bool {
+	tree := dijkstra[int, W](net, source, pq.NewMin[W](net.numNodes))
+	inf := infinity[W]()
+	bound := tree.dist[sink]
+	if bound == inf {
+		return false
+	}
+	// potentials stay valid for all arcs if distances beyond the sink are cut off
+	for v, dist := range tree.dist {
+		if dist > bound {
+			dist = bound
+		}
+		net.potential[v] += dist
+	}
+	for v := sink; v != source; v = tree.pre[v] {
+		a := net.arcBetween(tree.pre[v], v)
+		net.free[a] = false
+		net.free[a^1] = true
+	}
+	return true
+}
+
+// arcBetween returns the usable arc from u to v with the smallest reduced weight
+func (net *residualNetwork[W]) arcBetween(u, v int) int {
+	best := -1
+	for _, a := range net.adj[u] {
+		if net.free[a] && net.to[a] == v && (best == -1 || net.reduced(a) < net.reduced(best)) {
+			best = a
+		}
+	}
+	return best
+}
+
+// flows reports whether a unit of flow runs over the arc a of the graph
+func (net *residualNetwork[W]) flows(a int) bool {
+	return a%2 == 0 && !net.free[a]
+}
+
+// decompose splits the flow into k paths of dense graph nodes
+func (net *residualNetwork[W]) decompose(source, sink, k int) []densePath[W] {
+	// two paths that use an edge in opposite directions can swap their tails, which drops
+	// the edge from both. For undirected graphs this is what makes the paths edge disjoint.
+	for a := 0; a < len(net.to); a += 2 {
+		if !net.flows(a) {
+			continue
+		}
+		for _, b := range net.adj[net.to[a]] {
+			if b != a^1 && net.flows(b) && net.to[b] == net.to[a^1] {
+				net.free[a], net.free[a^1] = true, false
+				net.free[b], net.free[b^1] = true, false
+				break
+			}
+		}
+	}
+
+	paths := make([]densePath[W], 0, k)
+	for i := 0; i < k; i++ {
+		// follow the flow from source to sink, costs holds the cost up to every node
+		nodes, costs := []int{source}, []W{0}
+		pos := map[int]int{source: 0}
+		for u := source; u != sink; {
+			cost := costs[len(costs)-1]
+			for _, a := range net.adj[u] {
+				if net.flows(a) {
+					net.free[a] = true
+					u = net.to[a]
+					cost += net.weight[a]
+					break
+				}
+			}
+			// a cycle of zero weight does not belong to the path
+			if j, ok := pos[u]; ok {
+				for _, v := range nodes[j+1:] {
+					delete(pos, v)
+				}
+				nodes, costs = nodes[:j+1], costs[:j+1]
+				continue
+			}
+			pos[u] = len(nodes)
+			nodes, costs = append(nodes, u), append(costs, cost)
+		}
+
+		path := densePath[W]{cost: costs[len(costs)-1]}
+		for j, v := range nodes {
+			// the entry and exit of a split node are the same node of the graph
+			if j == 0 || net.original[v] != net.original[nodes[j-1]] {
+				path.nodes = append(path.nodes, net.original[v])
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func (g *GraphOf[N, W]) EdgeDisjointPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return EdgeDisjointPaths[N, W](g, start, goal, k)
+}
+
+func (g *GraphOf[N, W]) NodeDisjointPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return NodeDisjointPaths[N, W](g, start, goal, k)
+}
@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Example Graph:
+// .                 1    ┌─────┐    2
+// .         ┌──────────► │  a  │ ──────────┐
+// .         │            └──┬──┘           ▼
+// .      ┌──┴──┐            │ 1         ┌─────┐
+// .      │  s  │            ▼           │  t  │
+// .      └──┬──┘         ┌─────┐        └─────┘
+// .         │     2      │  b  │    1      ▲
+// .         └──────────► └──┬──┘ ──────────┘
+//
+// The shortest path s -> a -> b -> t blocks every second path,
+// the best pair avoids the arc a -> b.
+func TestEdgeDisjointPaths(t *testing.T) {
+	g := NewGraphOf[string, int]()
+	g.AddEdge("s", "a", 1)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "t", 1)
+	g.AddEdge("s", "b", 2)
+	g.AddEdge("a", "t", 2)
+
+	expect := []PathOf[string, int]{
+		{[]string{"s", "a", "t"}, 3},
+		{[]string{"s", "b", "t"}, 3},
+	}
+	for _, disjoint := range []func(Reader[string, int], string, string, int) ([]PathOf[string, int], error){
+		EdgeDisjointPaths[string, int],
+		NodeDisjointPaths[string, int],
+	} {
+		paths, err := disjoint(g, "s", "t", 2)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(paths, expect) {
+			t.Errorf("expected %v, got %v", expect, paths)
+		}
+	}
+
+	if _, err := EdgeDisjointPaths[string, int](g, "s", "t", 3); !errors.Is(err, ErrNotEnoughPaths) {
+		t.Errorf("expected ErrNotEnoughPaths, got %v", err)
+	}
+	if _, err := EdgeDisjointPaths[string, int](g, "t", "s", 1); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+	// no path at all is also not enough paths
+	for _, find := range []func(Reader[string, int], string, string, int) ([]PathOf[string, int], error){
+		EdgeDisjointPaths[string, int], NodeDisjointPaths[string, int],
+	} {
+		_, err := find(g, "t", "s", 2)
+		if !errors.Is(err, ErrNotEnoughPaths) || !errors.Is(err, ErrNoPath) {
+			t.Errorf("expected ErrNotEnoughPaths and ErrNoPath, got %v", err)
+		}
+	}
+}
+
+func TestDisjointPathsUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(1, 3, 2)
+
+	paths, err := g.EdgeDisjointPaths(0, 3, 2)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := []Path{
+		{[]int{0, 1, 3}, 3},
+		{[]int{0, 2, 3}, 3},
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v", expect, paths)
+	}
+}
+
+// Example Graph: every path runs through m
+// .   s ──► a ──► m ──► c ──► t
+// .   s ──► b ──► m ──► d ──► t
+func TestNodeDisjointPaths(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 5, 1)
+	g.AddEdge(2, 5, 1)
+	g.AddEdge(5, 3, 1)
+	g.AddEdge(5, 4, 1)
+	g.AddEdge(3, 9, 1)
+	g.AddEdge(4, 9, 1)
+
+	paths, err := g.EdgeDisjointPaths(0, 9, 2)
+	if err != nil || len(paths) != 2 {
+		t.Errorf("expected two edge disjoint paths, got %v, %v", paths, err)
+	}
+	if _, err := g.NodeDisjointPaths(0, 9, 2); !errors.Is(err, ErrNotEnoughPaths) {
+		t.Errorf("expected ErrNotEnoughPaths, got %v", err)
+	}
+	paths, err = g.NodeDisjointPaths(0, 9, 1)
+	if err != nil || len(paths) != 1 || paths[0].Cost != 4 {
+		t.Errorf("expected a single path with cost 4, got %v, %v", paths, err)
+	}
+}
+
+// simplePaths enumerates all simple paths from u to goal
+func simplePaths(g *GraphOf[int, int], u, goal int, path []int, res *[][]int) {
+	path = append(path, u)
+	if u == goal {
+		*res = append(*res, append([]int{}, path...))
+		return
+	}
+	for _, e := range g.Neighbors(u) {
+		visited := false
+		for _, v := range path {
+			visited = visited || v == e.To
+		}
+		if !visited {
+			simplePaths(g, e.To, goal, path, res)
+		}
+	}
+}
+
+func TestDisjointPathsMatchEnumeration(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rnd := rand.New(rand.NewSource(seed))
+		g := NewGraphOf[int, int]()
+		for i := 0; i < 25; i++ {
+			g.AddEdge(rnd.Intn(8), rnd.Intn(8), rnd.Intn(10)+1)
+		}
+		if !g.HasNode(0) || !g.HasNode(7) {
+			continue
+		}
+
+		all := make([][]int, 0)
+		simplePaths(g, 0, 7, nil, &all)
+		bestEdge, bestNode := -1, -1
+		for i, p := range all {
+			for _, q := range all[i+1:] {
+				cost := pathCost(g, p) + pathCost(g, q)
+				if disjointArcs(p, q) && (bestEdge == -1 || cost < bestEdge) {
+					bestEdge = cost
+				}
+				if disjointInner(p, q) && (bestNode == -1 || cost < bestNode) {
+					bestNode = cost
+				}
+			}
+		}
+
+		for _, c := range []struct {
+			best  int
+			paths func(int, int, int) ([]PathOf[int, int], error)
+		}{{bestEdge, g.EdgeDisjointPaths}, {bestNode, g.NodeDisjointPaths}} {
+			paths, err := c.paths(0, 7, 2)
+			if c.best == -1 {
+				if err == nil {
+					t.Errorf("seed %d: expected an error, got %v", seed, paths)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("seed %d: %v", seed, err)
+				continue
+			}
+			if paths[0].Cost+paths[1].Cost != c.best {
+				t.Errorf("seed %d: expected total cost %d, got %v", seed, c.best, paths)
+			}
+		}
+	}
+}
+
+func pathCost(g *GraphOf[int, int], p []int) int {
+	cost := 0
+	for i := 1; i < len(p); i++ {
+		w, _ := g.Weight(p[i-1], p[i])
+		cost += w
+	}
+	return cost
+}
+
+func disjointArcs(p, q []int) bool {
+	for i := 1; i < len(p); i++ {
+		for j := 1; j < len(q); j++ {
+			if p[i-1] == q[j-1] && p[i] == q[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func disjointInner(p, q []int) bool {
+	for _, u := range p[1 : len(p)-1] {
+		for _, v := range q[1 : len(q)-1] {
+			if u == v {
+				return false
+			}
+		}
+	}
+	return disjointArcs(p, q)
+}
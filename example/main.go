@@ -31,7 +31,7 @@ func main() {
 	fmt.Println("Graph:", g3)
 	fmt.Println()
 
-	g, err := graph.FromAdjMat([]float64{
+	g, err := graph.FromAdjMatUndirected([]float64{
 		0, 1, 1, 0, 0, 0,
 		1, 0, 0, 1, 1, 0,
 		1, 0, 0, 0, 1, 0,
@@ -77,7 +77,7 @@ func main() {
 	fmt.Println()
 
 	fmt.Println("All pairs shortest path:")
-	dist := g.FloydWarshall()
+	dist := g.FloydWarshall().Matrix()
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
 			fmt.Printf("%.2f ", dist[i*n+j])
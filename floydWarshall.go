@@ -1,39 +1,71 @@
 package graph
 
-import "math"
+// AllPairsOf holds the shortest distances between all pairs of nodes and the next hop
+// on every shortest path, both as flat V x V arrays with rows and columns ordered like Nodes().
+type AllPairsOf[N comparable, W Number] struct {
+	nodes denseView[N, W]
+	dist  []W
+	next  []int // dense index of the node after i on the path from i to j, -1 if there is none
+	ind   func(int, int) int
+}
+
+type AllPairs = AllPairsOf[int, float64]
 
 // Floyd Warshall algorithm for finding all-pairs shortest paths in a graph.
 //
-// returns a matrix, given as a flat array of shortest distances between all nodes in the graph
+// returns the shortest distances and paths between all nodes in the graph.
+// Unreachable pairs have distance infinity (+Inf for floating point weights,
+// the largest representable value for integer weights).
 //
 // Time Complexity: O(V^3)
-func (g *Graph) FloydWarshall() []float64 {
-	numNodes := g.NumNodes()
-	dist := make([]float64, numNodes*numNodes)
+func FloydWarshall[N comparable, W Number](g Reader[N, W]) *AllPairsOf[N, W] {
+	d := viewOf(g)
+	numNodes := d.len()
+	inf := infinity[W]()
+	dist := make([]W, numNodes*numNodes)
+	next := make([]int, numNodes*numNodes)
 	for i := 0; i < numNodes*numNodes; i++ {
-		dist[i] = math.Inf(1)
+		dist[i] = inf
+		next[i] = -1
 	}
 	ind := index(numNodes)
 
-	for _, e := range g.Edges() {
-		dist[ind(e.From, e.To)] = e.Weight
-	}
-
 	for k := 0; k < numNodes; k++ {
 		dist[ind(k, k)] = 0 // distance from a node to itself is 0
+		next[ind(k, k)] = k
+	}
+
+	for from := 0; from < numNodes; from++ {
+		targets, weights := d.arcs(from)
+		for j, to := range targets {
+			// of several parallel edges only the lightest one matters,
+			// a negative self loop makes the diagonal negative
+			if i := ind(from, to); weights[j] < dist[i] {
+				dist[i] = weights[j]
+				next[i] = to
+			}
+		}
 	}
 
 	for k := 0; k < numNodes; k++ {
 		for i := 0; i < numNodes; i++ {
+			if dist[ind(i, k)] == inf {
+				continue
+			}
 			for j := 0; j < numNodes; j++ {
-				if dist[ind(i, k)]+dist[ind(k, j)] < dist[ind(i, j)] {
+				if dist[ind(k, j)] != inf && dist[ind(i, k)]+dist[ind(k, j)] < dist[ind(i, j)] {
 					dist[ind(i, j)] = dist[ind(i, k)] + dist[ind(k, j)]
+					next[ind(i, j)] = next[ind(i, k)]
 				}
 			}
 		}
 	}
 
-	return dist
+	return &AllPairsOf[N, W]{d, dist, next, ind}
+}
+
+func (g *GraphOf[N, W]) FloydWarshall() *AllPairsOf[N, W] {
+	return FloydWarshall[N, W](g)
 }
 
 func index(n int) func(int, int) int {
@@ -41,3 +73,63 @@ func index(n int) func(int, int) int {
 		return n*i + j
 	}
 }
+
+// Matrix returns the flat V x V distance matrix, rows and columns are ordered like Nodes()
+func (a *AllPairsOf[N, W]) Matrix() []W {
+	return a.dist
+}
+
+// HasNegativeCycle reports whether the graph contains a cycle with negative total weight,
+// which shows as a negative distance from a node to itself
+func (a *AllPairsOf[N, W]) HasNegativeCycle() bool {
+	for k := 0; k < a.nodes.len(); k++ {
+		if a.dist[a.ind(k, k)] < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Dist returns the length of the shortest path from u to v, infinity if there is none
+// and -infinity if the path can run through a negative cycle
+func (a *AllPairsOf[N, W]) Dist(u, v N) W {
+	i, okU := a.nodes.index(u)
+	j, okV := a.nodes.index(v)
+	if !okU || !okV {
+		return infinity[W]()
+	}
+	if a.unbounded(i, j) {
+		return negativeInfinity[W]()
+	}
+	return a.dist[a.ind(i, j)]
+}
+
+// Path returns the nodes on the shortest path from u to v (both included) and its length.
+// It reports false if there is no path or if it can run through a negative cycle.
+func (a *AllPairsOf[N, W]) Path(u, v N) ([]N, W, bool) {
+	i, okU := a.nodes.index(u)
+	j, okV := a.nodes.index(v)
+	if !okU || !okV || a.next[a.ind(i, j)] == -1 {
+		return nil, infinity[W](), false
+	}
+	if a.unbounded(i, j) {
+		return nil, negativeInfinity[W](), false
+	}
+	path := []N{u}
+	for k := i; k != j; {
+		k = a.next[a.ind(k, j)]
+		path = append(path, a.nodes.node(k))
+	}
+	return path, a.dist[a.ind(i, j)], true
+}
+
+// unbounded reports whether a path from i to j can pass a node on a negative cycle
+func (a *AllPairsOf[N, W]) unbounded(i, j int) bool {
+	inf := infinity[W]()
+	for k := 0; k < a.nodes.len(); k++ {
+		if a.dist[a.ind(k, k)] < 0 && a.dist[a.ind(i, k)] != inf && a.dist[a.ind(k, j)] != inf {
+			return true
+		}
+	}
+	return false
+}
@@ -25,7 +25,7 @@ func TestFloydWarshall(t *testing.T) {
 	g.AddEdge(1, 2, 3)
 	g.AddEdge(2, 3, 1)
 
-	m := g.FloydWarshall()
+	m := g.FloydWarshall().Matrix()
 	expect := []float64{
 		0, 5, 8, 9,
 		math.Inf(1), 0, 3, 4,
@@ -65,7 +65,7 @@ func TestFloydWarshall2(t *testing.T) {
 		{3, 1, -1},
 	})
 
-	m := g.FloydWarshall()
+	m := g.FloydWarshall().Matrix()
 	expect := []float64{
 		0, -1, -2, 0,
 		4, 0, 2, 4,
@@ -77,3 +77,111 @@ func TestFloydWarshall2(t *testing.T) {
 		t.Errorf("expected: %v, got: %v", expect, m)
 	}
 }
+
+func TestFloydWarshallSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 5)
+	g.AddEdge(100, 7000, 3)
+
+	// rows and columns are ordered like g.Nodes(): 3, 100, 7000
+	m := g.FloydWarshall().Matrix()
+	expect := []float64{
+		0, 5, 8,
+		math.Inf(1), 0, 3,
+		math.Inf(1), math.Inf(1), 0,
+	}
+
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("expected: %v, got: %v", expect, m)
+	}
+}
+
+func TestFloydWarshallIntWeights(t *testing.T) {
+	g := NewGraphOf[int, int]()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(1, 2, -3)
+
+	m := g.FloydWarshall().Matrix()
+	inf := infinity[int]()
+	expect := []int{
+		0, 5, 2,
+		inf, 0, -3,
+		inf, inf, 0,
+	}
+
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("expected: %v, got: %v", expect, m)
+	}
+}
+
+func TestFloydWarshallUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(1, 2, 3)
+
+	m := g.FloydWarshall().Matrix()
+	expect := []float64{
+		0, 5, 8,
+		5, 0, 3,
+		8, 3, 0,
+	}
+
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("expected: %v, got: %v", expect, m)
+	}
+}
+
+func TestFloydWarshallPath(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(0, 3, 10)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, 1)
+
+	ap := g.FloydWarshall()
+	path, cost, ok := ap.Path(0, 3)
+	expect := []int{0, 1, 2, 3}
+	if !ok || !reflect.DeepEqual(path, expect) || cost != 9 {
+		t.Errorf("expected %v with cost 9, got %v with cost %v", expect, path, cost)
+	}
+	if ap.Dist(1, 3) != 4 || !math.IsInf(ap.Dist(3, 0), 1) {
+		t.Errorf("expected distance 4 from 1 to 3 and none from 3 to 0")
+	}
+	if _, _, ok := ap.Path(3, 0); ok {
+		t.Errorf("expected no path from 3 to 0")
+	}
+	if path, _, ok := ap.Path(2, 2); !ok || !reflect.DeepEqual(path, []int{2}) {
+		t.Errorf("expected the path [2], got %v", path)
+	}
+	if ap.HasNegativeCycle() {
+		t.Errorf("expected no negative cycle")
+	}
+}
+
+func TestFloydWarshallNegativeCycle(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -3)
+	g.AddEdge(2, 1, 1)
+	g.AddEdge(0, 4, 2)
+
+	ap := g.FloydWarshall()
+	if !ap.HasNegativeCycle() {
+		t.Errorf("expected a negative cycle")
+	}
+	if !math.IsInf(ap.Dist(0, 2), -1) {
+		t.Errorf("expected the distance from 0 to 2 to be -Inf, got %v", ap.Dist(0, 2))
+	}
+	if _, _, ok := ap.Path(0, 2); ok {
+		t.Errorf("expected no shortest path from 0 to 2")
+	}
+	if path, cost, ok := ap.Path(0, 4); !ok || cost != 2 || !reflect.DeepEqual(path, []int{0, 4}) {
+		t.Errorf("expected [0 4] with cost 2, got %v with cost %v", path, cost)
+	}
+
+	loop := NewGraph()
+	loop.AddEdge(0, 0, -1)
+	if !loop.FloydWarshall().HasNegativeCycle() {
+		t.Errorf("expected a negative self loop to be a negative cycle")
+	}
+}
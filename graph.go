@@ -6,33 +6,84 @@ import (
 	"sort"
 )
 
-type Edge struct {
-	From, To int
-	Weight   float64
+type EdgeOf[N comparable, W Number] struct {
+	From, To N
+	Weight   W
 }
 
-func (e *Edge) Equals(e2 *Edge) bool {
+func (e *EdgeOf[N, W]) Equals(e2 *EdgeOf[N, W]) bool {
 	return e.From == e2.From && e.To == e2.To && e.Weight == e2.Weight
 }
 
-type WeightTuple struct {
-	To     int
-	Weight float64
+type WeightTupleOf[N comparable, W Number] struct {
+	To     N
+	Weight W
 }
 
-// mapping from node index to list of tuples (node, weight)
-type AdjList map[int][]WeightTuple
+// mapping from node to list of tuples (node, weight)
+type AdjListOf[N comparable, W Number] map[N][]WeightTupleOf[N, W]
 
-type Graph struct {
-	AdjacencyList AdjList
+// GraphOf is a weighted graph with nodes of type N and weights of type W.
+// Graphs are directed unless created by one of the undirected constructors.
+// An undirected edge is a single edge, but it is listed in the adjacency lists of both endpoints.
+type GraphOf[N comparable, W Number] struct {
+	AdjacencyList AdjListOf[N, W]
+	undirected    bool
+	multi         bool
+
+	// ids of the arcs in AdjacencyList, edgeIDs[v][i] belongs to AdjacencyList[v][i].
+	// Both arcs of an undirected edge share the same id.
+	edgeIDs  map[N][]EdgeID
+	edgeEnds map[EdgeID][2]N // id -> (from, to)
+	nextID   EdgeID
+
+	// attributes are created lazily, so graphs without attributes don't pay for them
+	nodeAttrs map[N]Attributes
+	edgeAttrs map[EdgeID]Attributes
+
+	// reverse adjacency index: node -> predecessor -> number of arcs from the predecessor.
+	// It is kept in sync with AdjacencyList by all methods that change the graph.
+	incoming map[N]map[N]int
+
+	// insertion order of the nodes, used to order nodes that have no natural order
+	seq     map[N]int
+	nextSeq int
 }
 
+// Non-generic aliases for graphs with int nodes and float64 weights
+type (
+	Graph       = GraphOf[int, float64]
+	Edge        = EdgeOf[int, float64]
+	WeightTuple = WeightTupleOf[int, float64]
+	AdjList     = AdjListOf[int, float64]
+)
+
 func NewGraph() *Graph {
-	return &Graph{make(map[int][]WeightTuple)}
+	return NewGraphOf[int, float64]()
 }
 
-func FromEdgeList(edges []Edge) *Graph {
-	g := NewGraph()
+func NewGraphOf[N comparable, W Number]() *GraphOf[N, W] {
+	return &GraphOf[N, W]{
+		AdjacencyList: make(AdjListOf[N, W]),
+		edgeIDs:       make(map[N][]EdgeID),
+		edgeEnds:      make(map[EdgeID][2]N),
+		incoming:      make(map[N]map[N]int),
+		seq:           make(map[N]int),
+	}
+}
+
+func NewUndirectedGraph() *Graph {
+	return NewUndirectedGraphOf[int, float64]()
+}
+
+func NewUndirectedGraphOf[N comparable, W Number]() *GraphOf[N, W] {
+	g := NewGraphOf[N, W]()
+	g.undirected = true
+	return g
+}
+
+func FromEdgeList[N comparable, W Number](edges []EdgeOf[N, W]) *GraphOf[N, W] {
+	g := NewGraphOf[N, W]()
 	for _, e := range edges {
 		g.AddEdge(e.From, e.To, e.Weight)
 	}
@@ -40,43 +91,111 @@ func FromEdgeList(edges []Edge) *Graph {
 }
 
 // adj is the weighted adjacency matrix
-func FromAdjMat(adjMat []float64) (*Graph, error) {
+func FromAdjMat[W Number](adjMat []W) (*GraphOf[int, W], error) {
 	n := int(math.Sqrt(float64(len(adjMat))))
 	// make sure that the adjacency matrix is square
 	if n*n != len(adjMat) {
 		return nil, errors.New("incorrect number of edges")
 	}
 
-	adjList := make(map[int][]WeightTuple, n)
+	g := NewGraphOf[int, W]()
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+	}
 	for i := 0; i < n; i++ {
-		adjList[i] = []WeightTuple{}
 		for j := 0; j < n; j++ {
 			if adjMat[i*n+j] != 0 {
-				tuple := WeightTuple{j, adjMat[i*n+j]}
-				adjList[i] = append(adjList[i], tuple)
+				g.AddEdge(i, j, adjMat[i*n+j])
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// FromAdjMatUndirected creates an undirected graph from a symmetric weighted adjacency matrix
+func FromAdjMatUndirected[W Number](adjMat []W) (*GraphOf[int, W], error) {
+	n := int(math.Sqrt(float64(len(adjMat))))
+	// make sure that the adjacency matrix is square
+	if n*n != len(adjMat) {
+		return nil, errors.New("incorrect number of edges")
+	}
+
+	g := NewUndirectedGraphOf[int, W]()
+	for i := 0; i < n; i++ {
+		g.AddNode(i)
+		for j := i; j < n; j++ {
+			if adjMat[i*n+j] != adjMat[j*n+i] {
+				return nil, errors.New("adjacency matrix of an undirected graph must be symmetric")
+			}
+			if adjMat[i*n+j] != 0 {
+				g.AddEdge(i, j, adjMat[i*n+j])
 			}
 		}
 	}
 
-	return &Graph{adjList}, nil
+	return g, nil
 }
 
-func FromAdjList(adjList AdjList) *Graph {
-	return &Graph{adjList}
+func FromAdjList[N comparable, W Number](adjList AdjListOf[N, W]) *GraphOf[N, W] {
+	g := &GraphOf[N, W]{
+		AdjacencyList: adjList,
+		edgeIDs:       make(map[N][]EdgeID, len(adjList)),
+		edgeEnds:      make(map[EdgeID][2]N),
+		incoming:      make(map[N]map[N]int, len(adjList)),
+		seq:           make(map[N]int, len(adjList)),
+	}
+	// make sure that every edge target is a node of the graph
+	for v, adj := range adjList {
+		g.AddNode(v)
+		for _, e := range adj {
+			g.AddNode(e.To)
+			g.incoming[e.To][v]++
+			g.edgeIDs[v] = append(g.edgeIDs[v], g.nextID)
+			g.edgeEnds[g.nextID] = [2]N{v, e.To}
+			g.nextID++
+		}
+	}
+	return g
 }
 
-func (g *Graph) AddNode(val int) {
-	g.AdjacencyList[val] = []WeightTuple{}
+// IsDirected reports whether the edges of the graph are directed
+func (g *GraphOf[N, W]) IsDirected() bool {
+	return !g.undirected
 }
 
-func (g *Graph) HasNode(val int) bool {
+func (g *GraphOf[N, W]) AddNode(val N) {
+	if _, ok := g.seq[val]; !ok {
+		g.seq[val] = g.nextSeq
+		g.nextSeq++
+	}
+	if _, ok := g.incoming[val]; !ok {
+		g.incoming[val] = make(map[N]int)
+	}
+	if _, ok := g.edgeIDs[val]; !ok {
+		g.edgeIDs[val] = []EdgeID{}
+	}
+	if g.HasNode(val) {
+		return
+	}
+	g.AdjacencyList[val] = []WeightTupleOf[N, W]{}
+}
+
+func (g *GraphOf[N, W]) HasNode(val N) bool {
 	_, ok := g.AdjacencyList[val]
 	return ok
 }
 
-func (g *Graph) AddEdge(from, to int, weight float64) {
-	if g.Edge(from, to) != nil {
-		return
+// AddEdge adds an edge and returns its id. Unless the graph is a multigraph,
+// adding an edge between two nodes that are already connected is a no-op
+// which returns the id of the existing edge.
+func (g *GraphOf[N, W]) AddEdge(from, to N, weight W) EdgeID {
+	if !g.multi {
+		for i, e := range g.AdjacencyList[from] {
+			if e.To == to {
+				return g.edgeIDs[from][i]
+			}
+		}
 	}
 	if !g.HasNode(from) {
 		g.AddNode(from)
@@ -84,73 +203,256 @@ func (g *Graph) AddEdge(from, to int, weight float64) {
 	if !g.HasNode(to) {
 		g.AddNode(to)
 	}
-	g.AdjacencyList[from] = append(g.AdjacencyList[from], WeightTuple{to, weight})
+	id := g.nextID
+	g.addEdgeWithID(from, to, weight, id)
+	return id
+}
+
+// addEdgeWithID adds an edge with a given id, the nodes must already exist
+func (g *GraphOf[N, W]) addEdgeWithID(from, to N, weight W, id EdgeID) {
+	if id >= g.nextID {
+		g.nextID = id + 1
+	}
+	g.edgeEnds[id] = [2]N{from, to}
+	g.addArc(from, to, weight, id)
+	if g.undirected && from != to {
+		g.addArc(to, from, weight, id)
+	}
+}
+
+func (g *GraphOf[N, W]) addArc(from, to N, weight W, id EdgeID) {
+	g.AdjacencyList[from] = append(g.AdjacencyList[from], WeightTupleOf[N, W]{to, weight})
+	g.edgeIDs[from] = append(g.edgeIDs[from], id)
+	g.incoming[to][from]++
+}
+
+// RemoveEdge removes the edge between from and to, if there is one.
+// In a multigraph all parallel edges between from and to are removed.
+func (g *GraphOf[N, W]) RemoveEdge(from, to N) {
+	g.removeArcs(from, func(t N, _ EdgeID) bool { return t == to })
+	if g.undirected && from != to {
+		g.removeArcs(to, func(t N, _ EdgeID) bool { return t == from })
+	}
 }
 
-func (g *Graph) Edge(from, to int) *Edge {
+// RemoveNode removes the node val together with all of its incoming and outgoing edges
+func (g *GraphOf[N, W]) RemoveNode(val N) {
+	if !g.HasNode(val) {
+		return
+	}
+	// the reverse index tells us which adjacency lists point to val
+	for pre := range g.incoming[val] {
+		if pre != val {
+			g.removeArcs(pre, func(t N, _ EdgeID) bool { return t == val })
+		}
+	}
+	for i, e := range g.AdjacencyList[val] {
+		if e.To != val {
+			delete(g.incoming[e.To], val)
+		}
+		delete(g.edgeEnds, g.edgeIDs[val][i])
+		delete(g.edgeAttrs, g.edgeIDs[val][i])
+	}
+	delete(g.AdjacencyList, val)
+	delete(g.nodeAttrs, val)
+	delete(g.edgeIDs, val)
+	delete(g.incoming, val)
+	delete(g.seq, val)
+}
+
+// removeArcs removes the arcs starting at from for which drop returns true,
+// keeping the order of the remaining arcs
+func (g *GraphOf[N, W]) removeArcs(from N, drop func(to N, id EdgeID) bool) {
+	adj, ids := g.AdjacencyList[from], g.edgeIDs[from]
+	kept, keptIDs := adj[:0], ids[:0]
+	for i, e := range adj {
+		if !drop(e.To, ids[i]) {
+			kept = append(kept, e)
+			keptIDs = append(keptIDs, ids[i])
+			continue
+		}
+		delete(g.edgeEnds, ids[i])
+		delete(g.edgeAttrs, ids[i])
+		if g.incoming[e.To][from]--; g.incoming[e.To][from] == 0 {
+			delete(g.incoming[e.To], from)
+		}
+	}
+	g.AdjacencyList[from] = kept
+	g.edgeIDs[from] = keptIDs
+}
+
+func (g *GraphOf[N, W]) Edge(from, to N) *EdgeOf[N, W] {
 	adj := g.AdjacencyList[from]
 	for i, e := range adj {
 		if e.To == to {
-			return &Edge{from, to, adj[i].Weight}
+			return &EdgeOf[N, W]{from, to, adj[i].Weight}
 		}
 	}
 	return nil
 }
 
-func (g *Graph) Edges() []Edge {
-	edges := make([]Edge, 0)
+// Edges returns all edges of the graph. Undirected edges are returned once,
+// with From being the endpoint that comes first in g.Nodes().
+func (g *GraphOf[N, W]) Edges() []EdgeOf[N, W] {
+	edges := make([]EdgeOf[N, W], 0)
+	if g.undirected {
+		for _, e := range g.idEdges() {
+			edges = append(edges, e.EdgeOf)
+		}
+		return edges
+	}
 	for i, adj := range g.AdjacencyList {
 		for _, e := range adj {
-			edges = append(edges, Edge{i, e.To, e.Weight})
+			edges = append(edges, EdgeOf[N, W]{i, e.To, e.Weight})
 		}
 	}
 	return edges
 }
 
-func (g *Graph) UpdateEdge(from, to int, weight float64) {
+// idEdges returns all edges together with their ids, like Edges but ordered like g.Nodes()
+func (g *GraphOf[N, W]) idEdges() []MultiEdgeOf[N, W] {
+	edges := make([]MultiEdgeOf[N, W], 0)
+	idx := g.denseIndex()
+	for i, v := range idx.ids {
+		for j, e := range g.AdjacencyList[v] {
+			if !g.undirected || i <= idx.pos[e.To] {
+				edges = append(edges, MultiEdgeOf[N, W]{g.edgeIDs[v][j], EdgeOf[N, W]{v, e.To, e.Weight}})
+			}
+		}
+	}
+	return edges
+}
+
+// arcs returns every edge in the direction(s) it can be traversed,
+// i.e. undirected edges are returned once per direction.
+func (g *GraphOf[N, W]) arcs() []EdgeOf[N, W] {
+	if !g.undirected {
+		return g.Edges()
+	}
+	arcs := make([]EdgeOf[N, W], 0)
+	for i, adj := range g.AdjacencyList {
+		for _, e := range adj {
+			arcs = append(arcs, EdgeOf[N, W]{i, e.To, e.Weight})
+		}
+	}
+	return arcs
+}
+
+// UpdateEdge sets the weight of the edge between from and to.
+// In a multigraph only the first of the parallel edges is updated, use UpdateEdgeByID instead.
+func (g *GraphOf[N, W]) UpdateEdge(from, to N, weight W) {
 	for i, e := range g.AdjacencyList[from] {
 		if e.To == to {
+			g.UpdateEdgeByID(g.edgeIDs[from][i], weight)
+			return
+		}
+	}
+}
+
+// updateArc sets the weight of the arc with the given id starting at from
+func (g *GraphOf[N, W]) updateArc(from N, id EdgeID, weight W) {
+	for i, arcID := range g.edgeIDs[from] {
+		if arcID == id {
 			g.AdjacencyList[from][i].Weight = weight
 			return
 		}
 	}
 }
 
-func (g *Graph) Clone() *Graph {
-	adjList := make(map[int][]WeightTuple, len(g.AdjacencyList))
+func (g *GraphOf[N, W]) Clone() *GraphOf[N, W] {
+	adjList := make(AdjListOf[N, W], len(g.AdjacencyList))
 	for k, v := range g.AdjacencyList {
-		adjList[k] = make([]WeightTuple, len(v))
+		adjList[k] = make([]WeightTupleOf[N, W], len(v))
 		copy(adjList[k], v)
 	}
-	return &Graph{adjList}
+	edgeIDs := make(map[N][]EdgeID, len(g.edgeIDs))
+	for k, v := range g.edgeIDs {
+		edgeIDs[k] = make([]EdgeID, len(v))
+		copy(edgeIDs[k], v)
+	}
+	edgeEnds := make(map[EdgeID][2]N, len(g.edgeEnds))
+	for k, v := range g.edgeEnds {
+		edgeEnds[k] = v
+	}
+	incoming := make(map[N]map[N]int, len(g.incoming))
+	for k, v := range g.incoming {
+		incoming[k] = make(map[N]int, len(v))
+		for pre, n := range v {
+			incoming[k][pre] = n
+		}
+	}
+	seq := make(map[N]int, len(g.seq))
+	for k, v := range g.seq {
+		seq[k] = v
+	}
+	return &GraphOf[N, W]{
+		AdjacencyList: adjList,
+		undirected:    g.undirected,
+		multi:         g.multi,
+		edgeIDs:       edgeIDs,
+		edgeEnds:      edgeEnds,
+		nextID:        g.nextID,
+		nodeAttrs:     cloneAttrs(g.nodeAttrs),
+		edgeAttrs:     cloneAttrs(g.edgeAttrs),
+		incoming:      incoming,
+		seq:           seq,
+		nextSeq:       g.nextSeq,
+	}
 }
 
-func (g *Graph) Nodes() []int {
-	nodes := []int{}
+// Nodes returns all nodes of the graph. Nodes with an ordered underlying type
+// (integers, floats, strings) are sorted, all others are returned in insertion order.
+func (g *GraphOf[N, W]) Nodes() []N {
+	nodes := []N{}
 	for node := range g.AdjacencyList {
 		nodes = append(nodes, node)
 	}
-	sort.Ints(nodes)
+	if !sortNodes(nodes) {
+		sort.Slice(nodes, func(i, j int) bool { return g.seq[nodes[i]] < g.seq[nodes[j]] })
+	}
 	return nodes
 }
 
-func (g *Graph) NumNodes() int {
+func (g *GraphOf[N, W]) NumNodes() int {
 	return len(g.AdjacencyList)
 }
 
-func (g *Graph) NumEdges() int {
+func (g *GraphOf[N, W]) NumEdges() int {
 	return len(g.Edges())
 }
 
-func (g *Graph) AdjEdges(i int) []Edge {
-	edges := make([]Edge, 0)
+// Neighbors returns the arcs leaving v. The result must not be modified.
+func (g *GraphOf[N, W]) Neighbors(v N) []WeightTupleOf[N, W] {
+	return g.AdjacencyList[v]
+}
+
+// Weight returns the weight of the edge from u to v, or false if there is none.
+// In a multigraph it is the weight of the lightest of the parallel edges.
+func (g *GraphOf[N, W]) Weight(u, v N) (W, bool) {
+	var weight W
+	found := false
+	for _, e := range g.AdjacencyList[u] {
+		if e.To == v && (!found || e.Weight < weight) {
+			weight, found = e.Weight, true
+		}
+	}
+	return weight, found
+}
+
+// HasEdge reports whether there is an edge from u to v
+func (g *GraphOf[N, W]) HasEdge(u, v N) bool {
+	return g.incoming[v][u] > 0
+}
+
+func (g *GraphOf[N, W]) AdjEdges(i N) []EdgeOf[N, W] {
+	edges := make([]EdgeOf[N, W], 0)
 	for _, e := range g.AdjacencyList[i] {
-		edges = append(edges, Edge{i, e.To, e.Weight})
+		edges = append(edges, EdgeOf[N, W]{i, e.To, e.Weight})
 	}
 	return edges
 }
 
-func (g *Graph) HasNegativeEdges() bool {
+func (g *GraphOf[N, W]) HasNegativeEdges() bool {
 	for _, adj := range g.AdjacencyList {
 		for _, e := range adj {
 			if e.Weight < 0 {
@@ -161,15 +463,41 @@ func (g *Graph) HasNegativeEdges() bool {
 	return false
 }
 
-func (g *Graph) AsAdjMat() []float64 {
-	n := g.NumNodes()
-	adjMat := make([]float64, n*n)
+// AsAdjMat returns the weighted adjacency matrix as a flat array.
+// Rows and columns are ordered like g.Nodes(), the weights of parallel edges are added up.
+func (g *GraphOf[N, W]) AsAdjMat() []W {
+	return denseAdjMat(viewOf[N, W](g))
+}
+
+func denseAdjMat[N comparable, W Number](d denseView[N, W]) []W {
+	n := d.len()
+	adjMat := make([]W, n*n)
 	for i := 0; i < n; i++ {
-		for j := 0; j < n; j++ {
-			if g.Edge(i, j) != nil {
-				adjMat[i*n+j] = g.Edge(i, j).Weight
-			}
+		targets, weights := d.arcs(i)
+		for j, to := range targets {
+			adjMat[i*n+to] += weights[j]
 		}
 	}
 	return adjMat
 }
+
+// nodeIndex maps the (possibly sparse) node ids of a graph onto the dense
+// range 0..n-1, so algorithms can work with slices instead of maps.
+// The dense index of a node is its position in g.Nodes().
+type nodeIndex[N comparable] struct {
+	ids []N       // dense index -> node id
+	pos map[N]int // node id -> dense index
+}
+
+func (g *GraphOf[N, W]) denseIndex() *nodeIndex[N] {
+	ids := g.Nodes()
+	pos := make(map[N]int, len(ids))
+	for i, v := range ids {
+		pos[v] = i
+	}
+	return &nodeIndex[N]{ids, pos}
+}
+
+func (idx *nodeIndex[N]) len() int {
+	return len(idx.ids)
+}
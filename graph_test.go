@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -341,3 +342,237 @@ func TestGraphClone(t *testing.T) {
 		t.Errorf("expected g and g2 to be different, got %v and %v", g.Edge(0, 1), g2.Edge(0, 1))
 	}
 }
+
+func TestGenericGraph(t *testing.T) {
+	g := NewGraphOf[string, int]()
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 5)
+
+	if !reflect.DeepEqual(g.Nodes(), []string{"a", "b", "c"}) {
+		t.Errorf("expected nodes [a b c], got %v", g.Nodes())
+	}
+
+	edge := g.Edge("a", "b")
+	if edge == nil || edge.Weight != 1 {
+		t.Errorf("expected edge a -- 1 --> b, got %v", edge)
+	}
+}
+
+func TestGenericGraphInsertionOrder(t *testing.T) {
+	type node struct{ x, y int }
+	g := NewGraphOf[node, int]()
+	g.AddEdge(node{2, 2}, node{0, 0}, 1)
+	g.AddEdge(node{1, 1}, node{2, 2}, 1)
+
+	expected := []node{{2, 2}, {0, 0}, {1, 1}}
+	if !reflect.DeepEqual(g.Nodes(), expected) {
+		t.Errorf("expected nodes %v, got %v", expected, g.Nodes())
+	}
+}
+
+func TestGenericGraphNamedNodes(t *testing.T) {
+	type id uint16
+	g := NewGraphOf[id, int]()
+	g.AddEdge(300, 2, 1)
+	g.AddEdge(17, 300, 1)
+
+	if !reflect.DeepEqual(g.Nodes(), []id{2, 17, 300}) {
+		t.Errorf("expected nodes [2 17 300], got %v", g.Nodes())
+	}
+
+	type name string
+	h := NewGraphOf[name, int]()
+	h.AddEdge("c", "a", 1)
+	h.AddEdge("b", "c", 1)
+
+	if !reflect.DeepEqual(h.Nodes(), []name{"a", "b", "c"}) {
+		t.Errorf("expected nodes [a b c], got %v", h.Nodes())
+	}
+}
+
+func BenchmarkNodes(b *testing.B) {
+	g := randomGraph(100000, 100000, 1, false, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Nodes()
+	}
+}
+
+func TestInfinity(t *testing.T) {
+	if infinity[float64]() != math.Inf(1) {
+		t.Errorf("expected +Inf, got %v", infinity[float64]())
+	}
+	if infinity[int8]() != math.MaxInt8 {
+		t.Errorf("expected %d, got %d", math.MaxInt8, infinity[int8]())
+	}
+	if infinity[uint16]() != math.MaxUint16 {
+		t.Errorf("expected %d, got %d", math.MaxUint16, infinity[uint16]())
+	}
+	if infinity[int64]() != math.MaxInt64 {
+		t.Errorf("expected %d, got %d", int64(math.MaxInt64), infinity[int64]())
+	}
+}
+
+func TestUndirectedGraph(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 1, 3)
+	g.AddEdge(2, 2, 1)
+
+	if g.IsDirected() {
+		t.Errorf("expected an undirected graph")
+	}
+	if g.NumEdges() != 3 {
+		t.Errorf("expected 3 edges, got %d", g.NumEdges())
+	}
+
+	expected := []Edge{{0, 1, 2}, {1, 2, 3}, {2, 2, 1}}
+	if !reflect.DeepEqual(g.Edges(), expected) {
+		t.Errorf("expected edges %v, got %v", expected, g.Edges())
+	}
+
+	neighbors := g.AdjEdges(1)
+	expected = []Edge{{1, 0, 2}, {1, 2, 3}}
+	if !reflect.DeepEqual(neighbors, expected) {
+		t.Errorf("expected neighbors of 1 = %v, got %v", expected, neighbors)
+	}
+
+	g.UpdateEdge(1, 0, 5)
+	if g.Edge(0, 1).Weight != 5 || g.Edge(1, 0).Weight != 5 {
+		t.Errorf("expected edge 0 -- 1 to have weight 5 in both directions")
+	}
+}
+
+func TestFromAdjMatUndirected(t *testing.T) {
+	g, err := FromAdjMatUndirected([]float64{
+		0, 1, 2,
+		1, 0, 0,
+		2, 0, 0,
+	})
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if g.NumNodes() != 3 {
+		t.Errorf("expected 3 nodes, got %d", g.NumNodes())
+	}
+	if g.NumEdges() != 2 {
+		t.Errorf("expected 2 edges, got %d", g.NumEdges())
+	}
+
+	_, err = FromAdjMatUndirected([]float64{
+		0, 1,
+		0, 0,
+	})
+	if err == nil {
+		t.Error("expected error for an asymmetric matrix, got nil")
+	}
+}
+
+func TestRemoveEdge(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+
+	g.RemoveEdge(1, 2)
+	if g.NumEdges() != 2 {
+		t.Errorf("expected 2 edges, got %d", g.NumEdges())
+	}
+	if g.Edge(1, 2) != nil {
+		t.Errorf("expected edge 1 ---> 2 to be removed")
+	}
+	if g.NumNodes() != 3 {
+		t.Errorf("expected 3 nodes, got %d", g.NumNodes())
+	}
+
+	// removing an edge that does not exist is a no-op
+	g.RemoveEdge(0, 2)
+	if g.NumEdges() != 2 {
+		t.Errorf("expected 2 edges, got %d", g.NumEdges())
+	}
+
+	g.AddEdge(1, 2, 3)
+	if g.Edge(1, 2) == nil || g.Edge(1, 2).Weight != 3 {
+		t.Errorf("expected edge 1 -- 3 --> 2 to be added again")
+	}
+}
+
+func TestRemoveNode(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(1, 1, 1)
+
+	g.RemoveNode(1)
+	if g.HasNode(1) {
+		t.Errorf("expected node 1 to be removed")
+	}
+	expected := AdjList{
+		0: []WeightTuple{},
+		2: []WeightTuple{{0, 1}},
+		3: []WeightTuple{},
+	}
+	if !reflect.DeepEqual(g.AdjacencyList, expected) {
+		t.Errorf("expected %v, got %v", expected, g.AdjacencyList)
+	}
+
+	tree, err := g.Dijkstra(2)
+	if err != nil {
+		t.Error(err)
+	}
+	dist := tree.Distances()
+	expectDist := map[int]float64{0: 1, 2: 0, 3: math.Inf(1)}
+	if !reflect.DeepEqual(dist, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, dist)
+	}
+}
+
+func TestRemoveNodeUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+
+	g.RemoveNode(2)
+	expected := AdjList{
+		0: []WeightTuple{{1, 1}},
+		1: []WeightTuple{{0, 1}},
+	}
+	if !reflect.DeepEqual(g.AdjacencyList, expected) {
+		t.Errorf("expected %v, got %v", expected, g.AdjacencyList)
+	}
+
+	g.RemoveEdge(1, 0)
+	if g.NumEdges() != 0 {
+		t.Errorf("expected 0 edges, got %d", g.NumEdges())
+	}
+}
+
+func TestEdgeIDs(t *testing.T) {
+	g := NewGraph()
+	id1 := g.AddEdge(0, 1, 1)
+	id2 := g.AddEdge(1, 2, 2)
+	if id1 == id2 {
+		t.Errorf("expected different ids, got %d and %d", id1, id2)
+	}
+
+	// adding an existing edge to a simple graph returns the existing id
+	if id := g.AddEdge(0, 1, 5); id != id1 {
+		t.Errorf("expected id %d, got %d", id1, id)
+	}
+	if g.Edge(0, 1).Weight != 1 {
+		t.Errorf("expected edge 0 ---> 1 to keep weight 1, got %.2f", g.Edge(0, 1).Weight)
+	}
+
+	g.RemoveEdge(0, 1)
+	if g.EdgeByID(id1) != nil {
+		t.Errorf("expected edge %d to be removed", id1)
+	}
+	if e := g.EdgeByID(id2); e == nil || !e.Equals(&Edge{1, 2, 2}) {
+		t.Errorf("expected edge 1 -- 2 --> 2, got %v", e)
+	}
+}
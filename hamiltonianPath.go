@@ -3,11 +3,20 @@ package graph
 // Checks if the Graph has a Hamiltonian Path (a Path that visits every vertex exactly once)
 // using dynamic programming with time complexity O((2^n)*n^2).
 // Warning: dont use on large graphs (this problem is NP-complete)
-func (g *Graph) HasHamiltonianPathDP() bool {
+func HasHamiltonianPathDP[N comparable, W Number](g Reader[N, W]) bool {
+	d := viewOf(g)
+	numNodes := d.len()
+	adj := make([]bool, numNodes*numNodes)
+	for i := 0; i < numNodes; i++ {
+		targets, _ := d.arcs(i)
+		for _, to := range targets {
+			adj[i*numNodes+to] = true
+		}
+	}
+
 	// adapted from: https://www.hackerearth.com/practice/algorithms/graphs/hamiltonian-path/tutorial/
 	// initialize the dp matrix. dp[j][i] checks if there is a path that visits each vertex in
 	// the subset represented by the mask i and ends at vertex j.
-	numNodes := g.NumNodes()
 	numSubsets := 1 << uint(numNodes) // aka 2^numNodes
 	dp := make([][]bool, numNodes)
 	for i := 0; i < numNodes; i++ {
@@ -27,7 +36,7 @@ func (g *Graph) HasHamiltonianPathDP() bool {
 					// i^(1<<uint(j)) aka (i XOR 2^j) represents the subset S/{j}
 					// the cell dp[k][i^(1<<uint(j))] represents whether there is a path
 					// that visits each vertex in S/{j} exactly once and ends at vertex k.
-					if k != j && checkIthBit(k, i) && g.Edge(k, j) != nil && dp[k][i^(1<<uint(j))] {
+					if k != j && checkIthBit(k, i) && adj[k*numNodes+j] && dp[k][i^(1<<uint(j))] {
 						dp[j][i] = true
 						break
 					}
@@ -45,6 +54,10 @@ func (g *Graph) HasHamiltonianPathDP() bool {
 	return false
 }
 
+func (g *GraphOf[N, W]) HasHamiltonianPathDP() bool {
+	return HasHamiltonianPathDP[N, W](g)
+}
+
 func checkIthBit(i int, mask int) bool {
 	return (mask & (1 << uint(i))) != 0
 }
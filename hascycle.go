@@ -1,13 +1,19 @@
 package graph
 
-func (g *Graph) HasCycle() bool {
-	numNodes := g.NumNodes()
+// HasCycle checks if the graph contains a cycle.
+// For undirected graphs an edge is not considered a cycle on its own, but self loops are.
+func HasCycle[N comparable, W Number](g Reader[N, W]) bool {
+	d := viewOf(g)
+	numNodes := d.len()
 	visited := make([]bool, numNodes)
 	recStack := make([]bool, numNodes)
 
 	for i := 0; i < numNodes; i++ {
 		if !visited[i] {
-			if g.detectCycle(i, visited, recStack) {
+			if !g.IsDirected() && detectUndirectedCycle(d, i, -1, visited) {
+				return true
+			}
+			if g.IsDirected() && detectCycle(d, i, visited, recStack) {
 				return true
 			}
 		}
@@ -16,18 +22,39 @@ func (g *Graph) HasCycle() bool {
 	return false
 }
 
-func (g *Graph) detectCycle(start int, visited, recStack []bool) bool {
+func (g *GraphOf[N, W]) HasCycle() bool {
+	return HasCycle[N, W](g)
+}
+
+func detectCycle[N comparable, W Number](d denseView[N, W], start int, visited, recStack []bool) bool {
 	visited[start] = true
 	recStack[start] = true
-	for _, edge := range g.AdjEdges(start) {
-		if !visited[edge.To] {
-			if g.detectCycle(edge.To, visited, recStack) {
+	targets, _ := d.arcs(start)
+	for _, to := range targets {
+		if !visited[to] {
+			if detectCycle(d, to, visited, recStack) {
 				return true
 			}
-		} else if recStack[edge.To] {
+		} else if recStack[to] {
 			return true
 		}
 	}
 	recStack[start] = false
 	return false
 }
+
+// detectUndirectedCycle reports a cycle when a visited node other than the parent is reached
+func detectUndirectedCycle[N comparable, W Number](d denseView[N, W], start, parent int, visited []bool) bool {
+	visited[start] = true
+	targets, _ := d.arcs(start)
+	for _, to := range targets {
+		if !visited[to] {
+			if detectUndirectedCycle(d, to, start, visited) {
+				return true
+			}
+		} else if to != parent {
+			return true
+		}
+	}
+	return false
+}
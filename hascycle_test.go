@@ -141,3 +141,18 @@ func TestHasNoCycle2(t *testing.T) {
 		t.Errorf("expected false, got true")
 	}
 }
+
+func TestHasCycleUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	if g.HasCycle() {
+		t.Errorf("expected false, got true")
+	}
+
+	g.AddEdge(3, 2, 1)
+	if !g.HasCycle() {
+		t.Errorf("expected true, got false")
+	}
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/timHau/graph/pq"
+)
+
+// JohnsonPathsOf holds the reweighted graph of Johnson's algorithm.
+// Shortest path trees are computed on demand per source, so all pairs shortest paths
+// of large sparse graphs never need a dense V x V matrix.
+type JohnsonPathsOf[N comparable, W Number] struct {
+	d denseView[N, W]
+	// potential of every node, the distance from a virtual source with a zero weight arc to every node
+	potential  []W
+	reweighted *reweightedView[N, W]
+}
+
+type JohnsonPaths = JohnsonPathsOf[int, float64]
+
+// reweightedView is a dense view with the arc weights w(u, v) + potential(u) - potential(v),
+// which are non negative if potential is a shortest path distance
+type reweightedView[N comparable, W Number] struct {
+	denseView[N, W]
+	targets [][]int
+	weights [][]W
+}
+
+// Johnson prepares all pairs shortest paths with Johnson's algorithm.
+//
+// Bellman-Ford from a virtual source that is connected to every node with a zero weight arc
+// yields a potential for every node. Reweighting every arc with it removes all negative weights
+// without changing which paths are shortest, so Dijkstra can run from every source afterwards.
+//
+// Time Complexity: O(V * E) for the preparation, O((V + E) log V) per source
+// returns a *NegativeCycleError if the graph contains a negative cycle
+func Johnson[N comparable, W Number](g Reader[N, W]) (*JohnsonPathsOf[N, W], error) {
+	d := viewOf(g)
+	numNodes := d.len()
+
+	// the arcs of the virtual source are relaxed right away, every node starts at distance 0
+	potential := make([]W, numNodes)
+	pre := make([]int, numNodes)
+	for i := range pre {
+		pre[i] = -1
+	}
+	// the virtual source makes it V+1 nodes, so V rounds are needed
+	if relaxed := relaxArcs(d, potential, pre, numNodes); len(relaxed) > 0 {
+		return nil, negativeCycle(d, pre, relaxed[0])
+	}
+
+	rw := &reweightedView[N, W]{
+		denseView: d,
+		targets:   make([][]int, numNodes),
+		weights:   make([][]W, numNodes),
+	}
+	for from := 0; from < numNodes; from++ {
+		targets, weights := d.arcs(from)
+		rw.targets[from] = targets
+		rw.weights[from] = make([]W, len(weights))
+		for j, to := range targets {
+			rw.weights[from][j] = weights[j] + potential[from] - potential[to]
+		}
+	}
+
+	return &JohnsonPathsOf[N, W]{d, potential, rw}, nil
+}
+
+func (r *reweightedView[N, W]) arcs(i int) ([]int, []W) {
+	return r.targets[i], r.weights[i]
+}
+
+// From returns the shortest path tree rooted at source in the original graph
+func (j *JohnsonPathsOf[N, W]) From(source N) (*ShortestPathTreeOf[N, W], error) {
+	s, ok := j.d.index(source)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+	return j.from(s), nil
+}
+
+func (j *JohnsonPathsOf[N, W]) from(s int) *ShortestPathTreeOf[N, W] {
+	tree := dijkstra[N, W](j.reweighted, s, pq.NewMin[W](j.d.len()))
+	tree.nodes = j.d
+	// undo the reweighting, every path from s to v changed by potential(s) - potential(v)
+	inf := infinity[W]()
+	for v, dist := range tree.dist {
+		if dist != inf {
+			tree.dist[v] = dist - j.potential[s] + j.potential[v]
+		}
+	}
+	return tree
+}
+
+// ForEach calls fn with the shortest path tree of every node in the order of Nodes().
+// Only one tree is computed at a time.
+func (j *JohnsonPathsOf[N, W]) ForEach(fn func(tree *ShortestPathTreeOf[N, W])) {
+	for s := 0; s < j.d.len(); s++ {
+		fn(j.from(s))
+	}
+}
+
+func (g *GraphOf[N, W]) Johnson() (*JohnsonPathsOf[N, W], error) {
+	return Johnson[N, W](g)
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestJohnson(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -1)
+	g.AddEdge(0, 2, 4)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(1, 4, 2)
+	g.AddEdge(3, 2, 5)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(4, 3, -3)
+
+	j, err := g.Johnson()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, s := range g.Nodes() {
+		tree, err := j.From(s)
+		if err != nil {
+			t.Error(err)
+		}
+		bf, err := g.BellmanFord(s)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(tree.Distances(), bf.Distances()) {
+			t.Errorf("from %d expected %v, got %v", s, bf.Distances(), tree.Distances())
+		}
+	}
+
+	tree, err := j.From(0)
+	if err != nil {
+		t.Error(err)
+	}
+	path, cost, ok := tree.PathTo(3)
+	expect := []int{0, 1, 4, 3}
+	if !ok || !reflect.DeepEqual(path, expect) || cost != -2 {
+		t.Errorf("expected %v with cost -2, got %v with cost %v", expect, path, cost)
+	}
+
+	if _, err := j.From(42); err == nil {
+		t.Errorf("expected an error for a source that is not in the graph")
+	}
+}
+
+func TestJohnsonMatchesFloydWarshall(t *testing.T) {
+	// weights w + p(u) - p(v) with w >= 0 are often negative, but no cycle is
+	rnd := rand.New(rand.NewSource(4))
+	p := make([]int, 80)
+	for i := range p {
+		p[i] = rnd.Intn(100)
+	}
+	g := NewGraphOf[int, int]()
+	for i := range p {
+		g.AddNode(i)
+	}
+	for i := 0; i < 400; i++ {
+		u, v := rnd.Intn(80), rnd.Intn(80)
+		g.AddEdge(u, v, rnd.Intn(50)+p[u]-p[v])
+	}
+
+	j, err := g.Johnson()
+	if err != nil {
+		t.Error(err)
+	}
+	fw := g.FloydWarshall().Matrix()
+	nodes := g.Nodes()
+	s := 0
+	j.ForEach(func(tree *ShortestPathTreeOf[int, int]) {
+		if tree.Source != nodes[s] {
+			t.Errorf("expected source %d, got %d", nodes[s], tree.Source)
+		}
+		for i, v := range nodes {
+			if tree.DistTo(v) != fw[s*len(nodes)+i] {
+				t.Errorf("from %d to %d expected %d, got %d", tree.Source, v, fw[s*len(nodes)+i], tree.DistTo(v))
+			}
+		}
+		s++
+	})
+	if s != len(nodes) {
+		t.Errorf("expected %d trees, got %d", len(nodes), s)
+	}
+}
+
+func TestJohnsonNegativeCycle(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -2)
+	g.AddEdge(2, 1, 1)
+	g.AddNode(3)
+
+	_, err := g.Johnson()
+	var cycleErr *NegativeCycleError[int, float64]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a NegativeCycleError, got %v", err)
+	}
+	if len(cycleErr.Cycle) != 2 || cycleErr.Weight != -1 {
+		t.Errorf("expected the cycle 1 -> 2 -> 1 with weight -1, got %v with weight %v", cycleErr.Cycle, cycleErr.Weight)
+	}
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/timHau/graph/pq"
+)
+
+// PathOf is a path through a graph and its total weight.
+// It only holds the nodes, on multigraphs it does not tell which of several parallel edges is used.
+type PathOf[N comparable, W Number] struct {
+	Nodes []N
+	Cost  W
+}
+
+type Path = PathOf[int, float64]
+
+// Yen's algorithm for the k shortest loopless paths
+//
+// The first path is the shortest path. Every further path leaves one of the paths found so far
+// at a spur node: the part up to the spur node is kept, and the rest is the shortest path to goal
+// that neither uses a node of the kept part nor continues the way a known path with the same
+// prefix does. The cheapest of these candidates is the next path.
+//
+// Time Complexity: O(k * V * (V + E) log V)
+// returns up to k distinct simple paths from start to goal in non-decreasing cost order.
+// Paths are distinct as node sequences, on multigraphs only the lightest parallel edge is used.
+func KShortestPaths[N comparable, W Number](g Reader[N, W], start, goal N, k int) ([]PathOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("yen's algorithm does not support negative edge weights")
+	}
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+	t, ok := d.index(goal)
+	if !ok {
+		return nil, errors.New("goal node is not part of the graph")
+	}
+
+	if k <= 0 {
+		return []PathOf[N, W]{}, nil
+	}
+
+	m := newMaskedView(d)
+	first, cost, ok := m.shortestPath(s, t)
+	if !ok {
+		return nil, ErrNoPath
+	}
+	found := []densePath[W]{{first, cost}}
+	candidates := make([]densePath[W], 0)
+
+	for len(found) < k {
+		prev := found[len(found)-1].nodes
+		rootCost := W(0)
+		for i := 0; i < len(prev)-1; i++ {
+			spur, root := prev[i], prev[:i+1]
+			m.reset()
+			// the next path must not continue like a known path with the same root
+			for _, p := range found {
+				if len(p.nodes) > i+1 && equalPaths(p.nodes[:i+1], root) {
+					m.removeArc(p.nodes[i], p.nodes[i+1])
+				}
+			}
+			// and it must not run through the root again
+			for _, v := range root[:i] {
+				m.removeNode(v)
+			}
+
+			if spurPath, spurCost, ok := m.shortestPath(spur, t); ok {
+				path := append(append(make([]int, 0, i+len(spurPath)), root[:i]...), spurPath...)
+				candidate := densePath[W]{path, rootCost + spurCost}
+				if !containsPath(candidates, path) && !containsPath(found, path) {
+					candidates = append(candidates, candidate)
+				}
+			}
+			rootCost += lightestArc(d, prev[i], prev[i+1])
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		// the cheapest candidate comes next, on ties the one that was found first
+		sort.SliceStable(candidates, func(a, b int) bool { return candidates[a].cost < candidates[b].cost })
+		found = append(found, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	res := make([]PathOf[N, W], len(found))
+	for i, p := range found {
+		res[i] = PathOf[N, W]{make([]N, len(p.nodes)), p.cost}
+		for j, v := range p.nodes {
+			res[i].Nodes[j] = d.node(v)
+		}
+	}
+	return res, nil
+}
+
+// densePath is a path of dense node indices
+type densePath[W Number] struct {
+	nodes []int
+	cost  W
+}
+
+func equalPaths(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath[W Number](paths []densePath[W], path []int) bool {
+	for _, p := range paths {
+		if equalPaths(p.nodes, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskedView hides nodes and arcs of a dense view without copying it
+type maskedView[N comparable, W Number] struct {
+	denseView[N, W]
+	removedNodes []bool
+	removedArcs  map[[2]int]bool
+}
+
+func newMaskedView[N comparable, W Number](d denseView[N, W]) *maskedView[N, W] {
+	return &maskedView[N, W]{d, make([]bool, d.len()), make(map[[2]int]bool)}
+}
+
+func (m *maskedView[N, W]) reset() {
+	for i := range m.removedNodes {
+		m.removedNodes[i] = false
+	}
+	m.removedArcs = make(map[[2]int]bool)
+}
+
+func (m *maskedView[N, W]) removeNode(v int) {
+	m.removedNodes[v] = true
+}
+
+// removeArc removes all arcs from u to v
+func (m *maskedView[N, W]) removeArc(u, v int) {
+	m.removedArcs[[2]int{u, v}] = true
+}
+
+func (m *maskedView[N, W]) arcs(i int) ([]int, []W) {
+	if m.removedNodes[i] {
+		return nil, nil
+	}
+	targets, weights := m.denseView.arcs(i)
+	resTargets := make([]int, 0, len(targets))
+	resWeights := make([]W, 0, len(weights))
+	for j, to := range targets {
+		if !m.removedNodes[to] && !m.removedArcs[[2]int{i, to}] {
+			resTargets = append(resTargets, to)
+			resWeights = append(resWeights, weights[j])
+		}
+	}
+	return resTargets, resWeights
+}
+
+// shortestPath runs Dijkstra from s until t is settled
+func (m *maskedView[N, W]) shortestPath(s, t int) ([]int, W, bool) {
+	noEstimate := func(N) W { return 0 }
+	tree, _ := astar[N, W](m, s, t, noEstimate, pq.NewMin[W](m.len()))
+	if tree.dist[t] == infinity[W]() {
+		return nil, tree.dist[t], false
+	}
+	return tree.pathTo(t), tree.dist[t], true
+}
+
+func (g *GraphOf[N, W]) KShortestPaths(start, goal N, k int) ([]PathOf[N, W], error) {
+	return KShortestPaths[N, W](g, start, goal, k)
+}
@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Example Graph:
+// .             3           4           1
+// .   ┌───┐ ────────► ┌───┐ ──────► ┌───┐ ──────► ┌───┐
+// .   │ C │           │ D │         │ F │         │ H │
+// .   └───┘ ──┐       └───┘    ┌──► └───┘ ──┐     └───┘
+// .           │2        ▲ 1    │2           │2      ▲
+// .           ▼         │      │            ▼       │2
+// .         ┌───┐ ──────┘ ─────┘          ┌───┐ ────┘
+// .         │ E │ ──────────────────────► │ G │
+// .         └───┘           3             └───┘
+func TestKShortestPaths(t *testing.T) {
+	g := NewGraphOf[string, int]()
+	g.AddEdge("C", "D", 3)
+	g.AddEdge("C", "E", 2)
+	g.AddEdge("D", "F", 4)
+	g.AddEdge("E", "D", 1)
+	g.AddEdge("E", "F", 2)
+	g.AddEdge("E", "G", 3)
+	g.AddEdge("F", "G", 2)
+	g.AddEdge("F", "H", 1)
+	g.AddEdge("G", "H", 2)
+
+	paths, err := g.KShortestPaths("C", "H", 3)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := []PathOf[string, int]{
+		{[]string{"C", "E", "F", "H"}, 5},
+		{[]string{"C", "E", "G", "H"}, 7},
+		{[]string{"C", "D", "F", "H"}, 8},
+	}
+	if !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v", expect, paths)
+	}
+
+	paths, err = g.KShortestPaths("C", "H", 100)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(paths) != 7 {
+		t.Errorf("expected all 7 simple paths, got %v", paths)
+	}
+
+	if _, err := g.KShortestPaths("H", "C", 2); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected ErrNoPath, got %v", err)
+	}
+}
+
+// allSimplePathCosts enumerates the costs of all simple paths from u to goal
+func allSimplePathCosts(g *GraphOf[int, int], u, goal int, visited map[int]bool, cost int, res *[]int) {
+	if u == goal {
+		*res = append(*res, cost)
+		return
+	}
+	visited[u] = true
+	for _, e := range g.Neighbors(u) {
+		if !visited[e.To] {
+			allSimplePathCosts(g, e.To, goal, visited, cost+e.Weight, res)
+		}
+	}
+	visited[u] = false
+}
+
+func TestKShortestPathsMatchesEnumeration(t *testing.T) {
+	rnd := rand.New(rand.NewSource(8))
+	g := NewGraphOf[int, int]()
+	for i := 0; i < 40; i++ {
+		g.AddEdge(rnd.Intn(10), rnd.Intn(10), rnd.Intn(20))
+	}
+
+	costs := make([]int, 0)
+	allSimplePathCosts(g, 0, 9, map[int]bool{}, 0, &costs)
+	sort.Ints(costs)
+
+	paths, err := g.KShortestPaths(0, 9, 15)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(costs) > 15 {
+		costs = costs[:15]
+	}
+	if len(paths) != len(costs) {
+		t.Fatalf("expected %d paths, got %d", len(costs), len(paths))
+	}
+	for i, p := range paths {
+		if p.Cost != costs[i] {
+			t.Errorf("expected path %d to cost %d, got %v", i, costs[i], p)
+		}
+		seen := make(map[int]bool)
+		for _, v := range p.Nodes {
+			if seen[v] {
+				t.Errorf("expected a simple path, got %v", p.Nodes)
+			}
+			seen[v] = true
+		}
+		for _, q := range paths[:i] {
+			if reflect.DeepEqual(p.Nodes, q.Nodes) {
+				t.Errorf("expected distinct paths, got %v twice", p.Nodes)
+			}
+		}
+	}
+}
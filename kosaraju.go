@@ -5,22 +5,22 @@ package graph
 // returns a list of strongly connected components (scc), each of which is a list of nodes
 //
 // Time Complexity: O(V + E)
-func (g *Graph) Kosaraju() [][]int {
+func Kosaraju[N comparable, W Number](g Reader[N, W]) [][]N {
+	d := viewOf(g)
 	s := make([]int, 0)
-	visited := make([]bool, g.NumNodes())
+	visited := make([]bool, d.len())
 
-	for _, v := range g.Nodes() {
-		if !visited[v] {
-			g.DFSstep(v, visited, func(n int) {
-				s = append(s, n)
-			})
+	// push the nodes in order of their finishing time
+	for i := range visited {
+		if !visited[i] {
+			topologicalStep(d, i, visited, &s)
 		}
 	}
 
-	tg := g.Transpose()
+	tg := transposeOf(d)
 
-	scc := make([][]int, 0)
-	visited = make([]bool, g.NumNodes())
+	scc := make([][]N, 0)
+	visited = make([]bool, d.len())
 
 	for len(s) > 0 {
 		// pop from the stack
@@ -28,9 +28,9 @@ func (g *Graph) Kosaraju() [][]int {
 		s = s[:len(s)-1]
 
 		if !visited[v] {
-			scc = append(scc, make([]int, 0))
+			scc = append(scc, make([]N, 0))
 			// explore the component
-			tg.DFSstep(v, visited, func(n int) {
+			dfsStep[N, W](tg, v, visited, func(n N) {
 				scc[len(scc)-1] = append(scc[len(scc)-1], n)
 			})
 		}
@@ -38,3 +38,7 @@ func (g *Graph) Kosaraju() [][]int {
 
 	return scc
 }
+
+func (g *GraphOf[N, W]) Kosaraju() [][]N {
+	return Kosaraju[N, W](g)
+}
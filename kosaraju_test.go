@@ -59,3 +59,21 @@ func TestKosaraju2(t *testing.T) {
 		t.Errorf("expected %v, got %v", expect, scc)
 	}
 }
+
+func TestKosarajuSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 1)
+	g.AddEdge(100, 3, 1)
+	g.AddEdge(100, 7000, 1)
+
+	scc := g.Kosaraju()
+	for _, c := range scc {
+		sort.Ints(c)
+	}
+	sort.Slice(scc, func(i, j int) bool { return scc[i][0] < scc[j][0] })
+
+	expect := [][]int{{3, 100}, {7000}}
+	if !reflect.DeepEqual(scc, expect) {
+		t.Errorf("expected %v, got %v", expect, scc)
+	}
+}
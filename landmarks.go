@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/timHau/graph/pq"
+)
+
+// LandmarksOf holds the preprocessing of ALT (A*, landmarks and the triangle inequality).
+//
+// For every landmark L it stores the distances from L to all nodes and from all nodes to L.
+// By the triangle inequality d(v, t) >= d(L, t) - d(L, v) and d(v, t) >= d(v, L) - d(t, L),
+// which gives a lower bound on the distance between any two nodes without coordinates.
+// If a landmark reaches u but not v, or v reaches it but u does not, there is no path from u
+// to v at all and the bound is infinity. With these infinite bounds the heuristic is consistent,
+// so A* with it never expands a node twice.
+type LandmarksOf[N comparable, W Number] struct {
+	d, reverse denseView[N, W] // reverse is nil for undirected graphs
+	landmarks  []int
+	from, to   [][]W // from[l][v] = d(landmark l, v) and to[l][v] = d(v, landmark l)
+}
+
+type Landmarks = LandmarksOf[int, float64]
+
+// NewLandmarks runs Dijkstra from and to each of the given landmarks.
+//
+// Time Complexity: O(k (V + E) log V) for k landmarks
+// Space Complexity: O(k V)
+func NewLandmarks[N comparable, W Number](g Reader[N, W], landmarks []N) (*LandmarksOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("landmarks do not support negative edge weights")
+	}
+	l := newLandmarks(g, d)
+	for _, v := range landmarks {
+		i, ok := d.index(v)
+		if !ok {
+			return nil, errors.New("landmark is not part of the graph")
+		}
+		l.add(i)
+	}
+	return l, nil
+}
+
+// FarthestLandmarks picks k landmarks farthest-first: the first is the node farthest from the
+// first node, every next one the node farthest from all landmarks picked so far. Nodes that no
+// landmark reaches count as farthest, so every component that can be reached gets a landmark.
+//
+// Time Complexity: O(k (V + E) log V)
+func FarthestLandmarks[N comparable, W Number](g Reader[N, W], k int) (*LandmarksOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("landmarks do not support negative edge weights")
+	}
+	l := newLandmarks(g, d)
+	if d.len() == 0 {
+		return l, nil
+	}
+
+	// the distance of every node to the closest landmark
+	closest := dijkstra[N, W](d, 0, pq.NewMin[W](d.len())).dist
+	for len(l.landmarks) < k && len(l.landmarks) < d.len() {
+		next := -1
+		for v, dist := range closest {
+			if !l.isLandmark(v) && (next == -1 || dist > closest[next]) {
+				next = v
+			}
+		}
+		l.add(next)
+		if len(l.landmarks) == 1 {
+			// the first node only served to find the first landmark
+			closest = make([]W, d.len())
+			copy(closest, l.from[0])
+		}
+		for v, dist := range l.from[len(l.from)-1] {
+			if dist < closest[v] {
+				closest[v] = dist
+			}
+		}
+	}
+	return l, nil
+}
+
+// RandomLandmarks picks k distinct landmarks uniformly at random
+func RandomLandmarks[N comparable, W Number](g Reader[N, W], k int, rnd *rand.Rand) (*LandmarksOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("landmarks do not support negative edge weights")
+	}
+	l := newLandmarks(g, d)
+	perm := rnd.Perm(d.len())
+	for i := 0; i < k && i < len(perm); i++ {
+		l.add(perm[i])
+	}
+	return l, nil
+}
+
+func newLandmarks[N comparable, W Number](g Reader[N, W], d denseView[N, W]) *LandmarksOf[N, W] {
+	l := &LandmarksOf[N, W]{d: d}
+	// the backward searches run on the transposed graph, undirected graphs need none
+	if g.IsDirected() {
+		l.reverse = transposeOf(d)
+	}
+	return l
+}
+
+func (l *LandmarksOf[N, W]) add(v int) {
+	l.landmarks = append(l.landmarks, v)
+	from := dijkstra[N, W](l.d, v, pq.NewMin[W](l.d.len())).dist
+	to := from
+	if l.reverse != nil {
+		to = dijkstra[N, W](l.reverse, v, pq.NewMin[W](l.d.len())).dist
+	}
+	l.from = append(l.from, from)
+	l.to = append(l.to, to)
+}
+
+func (l *LandmarksOf[N, W]) isLandmark(v int) bool {
+	for _, u := range l.landmarks {
+		if u == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Landmarks returns the landmarks in the order they were picked
+func (l *LandmarksOf[N, W]) Landmarks() []N {
+	res := make([]N, len(l.landmarks))
+	for i, v := range l.landmarks {
+		res[i] = l.d.node(v)
+	}
+	return res
+}
+
+// LowerBound returns a lower bound on the distance from u to v, 0 if nothing is known
+// and infinity if the landmarks show that v can not be reached from u
+func (l *LandmarksOf[N, W]) LowerBound(u, v N) W {
+	i, ok := l.d.index(u)
+	j, ok2 := l.d.index(v)
+	if !ok || !ok2 {
+		return 0
+	}
+	return l.lowerBound(i, j)
+}
+
+func (l *LandmarksOf[N, W]) lowerBound(u, v int) W {
+	inf := infinity[W]()
+	var bound W
+	for k := range l.landmarks {
+		from, to := l.from[k], l.to[k]
+		// a path from u to v would extend L -> u to L -> v and v -> L to u -> L
+		if from[u] != inf && from[v] == inf || to[v] != inf && to[u] == inf {
+			return inf
+		}
+		// other infinite distances say nothing, the subtractions are ordered so unsigned weights can not wrap
+		if from[u] != inf && from[v] != inf && from[v] > from[u] && from[v]-from[u] > bound {
+			bound = from[v] - from[u]
+		}
+		if to[u] != inf && to[v] != inf && to[u] > to[v] && to[u]-to[v] > bound {
+			bound = to[u] - to[v]
+		}
+	}
+	return bound
+}
+
+// Heuristic returns the lower bound on the distance to goal as a heuristic for AStar,
+// nodes that can not reach goal are never queued:
+//
+//	path, cost, err := g.AStar(start, goal, landmarks.Heuristic(goal))
+func (l *LandmarksOf[N, W]) Heuristic(goal N) func(N) W {
+	t, ok := l.d.index(goal)
+	return func(v N) W {
+		i, ok2 := l.d.index(v)
+		if !ok || !ok2 {
+			return 0
+		}
+		return l.lowerBound(i, t)
+	}
+}
+
+func (g *GraphOf[N, W]) FarthestLandmarks(k int) (*LandmarksOf[N, W], error) {
+	return FarthestLandmarks[N, W](g, k)
+}
+
+func (g *GraphOf[N, W]) RandomLandmarks(k int, rnd *rand.Rand) (*LandmarksOf[N, W], error) {
+	return RandomLandmarks[N, W](g, k, rnd)
+}
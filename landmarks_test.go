@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Example Graph:
+// ┌─────┐   1   ┌─────┐   2   ┌─────┐   3   ┌─────┐
+// │  0  ├───────┤  1  ├───────┤  2  ├───────┤  3  │
+// └─────┘       └─────┘       └─────┘       └─────┘
+func TestLandmarks(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(2, 3, 3)
+
+	l, err := g.FarthestLandmarks(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 is farthest from the first node, then 0 is farthest from 3
+	if !reflect.DeepEqual(l.Landmarks(), []int{3, 0}) {
+		t.Errorf("expected landmarks [3 0], got %v", l.Landmarks())
+	}
+	// on a path every bound through an end is exact
+	if b := l.LowerBound(1, 2); b != 2 {
+		t.Errorf("expected the lower bound 2 from 1 to 2, got %v", b)
+	}
+	if b := l.LowerBound(1, 42); b != 0 {
+		t.Errorf("expected no bound for an unknown node, got %v", b)
+	}
+
+	path, cost, err := g.AStar(0, 3, l.Heuristic(3))
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(path, []int{0, 1, 2, 3}) || cost != 6 {
+		t.Errorf("expected [0 1 2 3] with cost 6, got %v with cost %v", path, cost)
+	}
+}
+
+func TestLandmarksNegativeWeights(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -1)
+	if _, err := g.FarthestLandmarks(1); err == nil {
+		t.Errorf("expected an error for negative weights")
+	}
+	if _, err := NewLandmarks[int, float64](g, []int{0}); err == nil {
+		t.Errorf("expected an error for negative weights")
+	}
+}
+
+func TestLandmarksMatchDijkstra(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		g := randomGraph(200, 800, 7, undirected, 1000000)
+		// a cycle through all nodes makes the directed graph strongly connected
+		for v := 0; v < 200; v++ {
+			g.AddEdge(v, (v+1)%200, 1000000)
+		}
+		farthest, err := g.FarthestLandmarks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		random, err := g.RandomLandmarks(4, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for s := 0; s < 200; s += 19 {
+			tree, err := g.Dijkstra(s)
+			if err != nil {
+				t.Error(err)
+			}
+			for goal := 0; goal < 200; goal += 7 {
+				_, expectCost, _ := tree.PathTo(goal)
+				for _, l := range []*Landmarks{farthest, random} {
+					if b := l.LowerBound(s, goal); b > expectCost {
+						t.Errorf("expected a lower bound from %d to %d, got %v > %v", s, goal, b, expectCost)
+					}
+					path, cost, err := g.AStar(s, goal, l.Heuristic(goal))
+					if err != nil || cost != expectCost || path[0] != s || path[len(path)-1] != goal {
+						t.Errorf("from %d to %d expected cost %v, got %v with cost %v (%v)", s, goal, expectCost, path, cost, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLandmarksConsistent(t *testing.T) {
+	// the debug checks of A* report every inconsistent estimate as an error
+	defer func(enabled bool) { debug = enabled }(debug)
+	debug = true
+
+	for seed := int64(0); seed < 5; seed++ {
+		// with 2 arcs per node the graph is far from strongly connected
+		g := randomGraph(100, 200, seed, false, 1000000)
+		l, err := g.FarthestLandmarks(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for s := 0; s < 100; s += 3 {
+			tree, err := g.Dijkstra(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for goal := 0; goal < 100; goal++ {
+				_, cost, err := g.AStar(s, goal, l.Heuristic(goal))
+				if !tree.Reachable(goal) {
+					if !errors.Is(err, ErrNoPath) {
+						t.Errorf("expected no path from %d to %d, got %v", s, goal, err)
+					}
+					continue
+				}
+				if err != nil || cost != tree.DistTo(goal) {
+					t.Errorf("from %d to %d expected cost %v, got %v (%v)", s, goal, tree.DistTo(goal), cost, err)
+				}
+			}
+		}
+	}
+
+	// the infinite estimate of an integer weight must not overflow
+	g := NewGraphOf[int, int]()
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(2, 1, 3)
+	l, err := g.FarthestLandmarks(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := l.LowerBound(1, 0); b != infinity[int]() {
+		t.Errorf("expected an infinite bound from 1 to 0, got %v", b)
+	}
+	if _, _, err := g.AStar(2, 0, l.Heuristic(0)); !errors.Is(err, ErrNoPath) {
+		t.Errorf("expected no path from 2 to 0, got %v", err)
+	}
+	if _, cost, err := g.AStar(2, 1, l.Heuristic(1)); err != nil || cost != 3 {
+		t.Errorf("expected cost 3 from 2 to 1, got %v (%v)", cost, err)
+	}
+}
+
+// countingReader counts how many nodes were expanded
+type countingReader struct {
+	Reader[int, float64]
+	expanded *int
+}
+
+func (g countingReader) Neighbors(v int) []WeightTuple {
+	*g.expanded++
+	return g.Reader.Neighbors(v)
+}
+
+func TestLandmarksExpandFewerNodes(t *testing.T) {
+	expanded := 0
+	g := countingReader{randomGrid(50, 50, 3), &expanded}
+	l, err := FarthestLandmarks[int, float64](g, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, goal := 10*50+5, 40*50+45
+	expanded = 0
+	_, expectCost, err := AStar[int, float64](g, start, goal, func(int) float64 { return 0 })
+	if err != nil {
+		t.Error(err)
+	}
+	dijkstraExpanded := expanded
+
+	expanded = 0
+	_, cost, err := AStar[int, float64](g, start, goal, l.Heuristic(goal))
+	if err != nil {
+		t.Error(err)
+	}
+	if cost != expectCost {
+		t.Errorf("expected cost %v, got %v", expectCost, cost)
+	}
+	if 2*expanded > dijkstraExpanded {
+		t.Errorf("expected far fewer expanded nodes than Dijkstra, got %d and %d", expanded, dijkstraExpanded)
+	}
+}
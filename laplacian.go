@@ -1,24 +1,31 @@
 package graph
 
 // Laplacian returns the Laplacian matrix of a graph as a flat array.
-func (g *Graph) Laplacian() []float64 {
-	numNodes := g.NumNodes()
+// Rows and columns are ordered like g.Nodes().
+func Laplacian[N comparable, W Number](g Reader[N, W]) []float64 {
+	d := viewOf(g)
+	numNodes := d.len()
 	degrees := make([]int, numNodes)
-	for _, v := range g.Nodes() {
-		degrees[v] = len(g.AdjEdges(v))
+	for i := 0; i < numNodes; i++ {
+		targets, _ := d.arcs(i)
+		degrees[i] = len(targets)
 	}
 
-	adjMat := g.AsAdjMat()
+	adjMat := denseAdjMat(d)
 	res := make([]float64, numNodes*numNodes)
 	for i := 0; i < numNodes; i++ {
 		for j := 0; j < numNodes; j++ {
 			if i == j {
 				res[i*numNodes+j] = float64(degrees[i])
 			} else {
-				res[i*numNodes+j] = -adjMat[i*numNodes+j]
+				res[i*numNodes+j] = -float64(adjMat[i*numNodes+j])
 			}
 		}
 	}
 
 	return res
 }
+
+func (g *GraphOf[N, W]) Laplacian() []float64 {
+	return Laplacian[N, W](g)
+}
@@ -1,52 +1,84 @@
 package graph
 
 import (
-	"math"
+	"errors"
+
+	"github.com/timHau/graph/pq"
 )
 
-func minKey(keys []float64, mstSet []bool) int {
-	min := math.Inf(1)
-	minIndex := 0
-	for i, v := range keys {
-		if v < min && !mstSet[i] {
-			min = v
-			minIndex = i
+// Prim's algorithm for finding a minimum spanning tree of an undirected graph.
+//
+// returns the minimum spanning tree as an undirected graph. If the graph is not connected,
+// the result is a minimum spanning forest with one tree per component.
+//
+// Time Complexity: O((V + E) log V)
+func Prim[N comparable, W Number](g Reader[N, W]) (*GraphOf[N, W], error) {
+	return PrimWithQueue[N, W](g, newMinQueue[W])
+}
+
+// PrimWithQueue is Prim with the min queue returned by newQueue for the items 0..n-1.
+// The keys of the queued nodes are edge weights, which are not popped in increasing order,
+// so monotone queues like pq.NewRadix can not be used.
+func PrimWithQueue[N comparable, W Number](g Reader[N, W], newQueue func(n int) pq.Queue[W]) (*GraphOf[N, W], error) {
+	if g.IsDirected() {
+		return nil, errors.New("prim's algorithm requires an undirected graph")
+	}
+
+	d := viewOf(g)
+	res := NewUndirectedGraphOf[N, W]()
+	parent, keys := spanningForest[N, W](d, newQueue(d.len()), pq.Less[W])
+	for i := 0; i < d.len(); i++ {
+		res.AddNode(d.node(i))
+		if parent[i] != -1 {
+			res.AddEdge(d.node(parent[i]), d.node(i), keys[i])
 		}
 	}
-	return minIndex
+
+	return res, nil
 }
 
-func (g *Graph) Prim() *Graph {
-	numNodes := g.NumNodes()
-	mstSet := make([]bool, numNodes)     // Set of nodes in the MST
-	mstKeys := make([]float64, numNodes) // dist values for each node
-	parent := make([]int, numNodes)      // Parent of each node in the MST
-	for i := 1; i < g.NumNodes(); i++ {
-		mstKeys[i] = math.Inf(1)
+// spanningForest grows a tree in every component with Prim's algorithm, from the first node of the component.
+// With a min queue and pq.Less it is a minimum, with a max queue and pq.Greater a maximum spanning forest.
+// returns the parent of every node and the weight of the edge to it, roots have parent -1
+func spanningForest[N comparable, W Number](d denseView[N, W], mq pq.Queue[W], better func(a, b W) bool) ([]int, []W) {
+	numNodes := d.len()
+	mstSet := make([]bool, numNodes)  // Set of nodes in the MST
+	reached := make([]bool, numNodes) // nodes with a key
+	mstKeys := make([]W, numNodes)    // weight of the best edge to each node
+	parent := make([]int, numNodes)   // Parent of each node in the MST
+	for i := 0; i < numNodes; i++ {
+		parent[i] = -1
 	}
 
-	curr := 0
-	mstKeys[curr] = 0
-	parent[curr] = -1
-
-	for i := 0; i < g.NumNodes()-1; i++ {
-		u := minKey(mstKeys, mstSet)
-		mstSet[u] = true
-		for _, e := range g.AdjEdges(u) {
-			if !mstSet[e.To] && mstKeys[e.To] > e.Weight {
-				mstKeys[e.To] = e.Weight
-				parent[e.To] = u
+	for root := 0; root < numNodes; root++ {
+		if mstSet[root] {
+			continue
+		}
+		// grow a new tree from the first node of every component
+		reached[root] = true
+		mq.Push(root, 0)
+		for mq.Len() > 0 {
+			u, _ := mq.Pop()
+			mstSet[u] = true
+			targets, weights := d.arcs(u)
+			for j, to := range targets {
+				if !mstSet[to] && (!reached[to] || better(weights[j], mstKeys[to])) {
+					reached[to] = true
+					mstKeys[to] = weights[j]
+					parent[to] = u
+					mq.Push(to, weights[j])
+				}
 			}
 		}
 	}
 
-	res := NewGraph()
-	for i := 1; i < g.NumNodes(); i++ {
-		res.AddNode(i)
-		if parent[i] != -1 {
-			res.AddEdge(parent[i], i, mstKeys[i])
-		}
-	}
+	return parent, mstKeys
+}
+
+func (g *GraphOf[N, W]) Prim() (*GraphOf[N, W], error) {
+	return Prim[N, W](g)
+}
 
-	return res
+func (g *GraphOf[N, W]) PrimWithQueue(newQueue func(n int) pq.Queue[W]) (*GraphOf[N, W], error) {
+	return PrimWithQueue[N, W](g, newQueue)
 }
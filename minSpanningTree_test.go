@@ -1,8 +1,11 @@
 package graph
 
 import (
+	"math/rand"
 	"reflect"
 	"testing"
+
+	"github.com/timHau/graph/pq"
 )
 
 // Example Graph:
@@ -51,25 +54,101 @@ func TestPrim(t *testing.T) {
 		8, 11, 0, 0, 0, 0, 1, 0, 7,
 		0, 0, 2, 0, 0, 0, 6, 7, 0,
 	}
-	g, err := FromAdjMat(adjMat)
+	g, err := FromAdjMatUndirected(adjMat)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	mst := g.Prim()
+	mst, err := g.Prim()
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
 	expected := AdjList{
 		0: []WeightTuple{{1, 4}},
-		1: []WeightTuple{{2, 8}},
-		2: []WeightTuple{{3, 7}, {5, 4}, {8, 2}},
-		3: []WeightTuple{{4, 9}},
-		4: []WeightTuple{},
-		5: []WeightTuple{{6, 2}},
-		6: []WeightTuple{{7, 1}},
-		7: []WeightTuple{},
-		8: []WeightTuple{},
+		1: []WeightTuple{{0, 4}, {2, 8}},
+		2: []WeightTuple{{1, 8}, {3, 7}, {5, 4}, {8, 2}},
+		3: []WeightTuple{{2, 7}, {4, 9}},
+		4: []WeightTuple{{3, 9}},
+		5: []WeightTuple{{2, 4}, {6, 2}},
+		6: []WeightTuple{{5, 2}, {7, 1}},
+		7: []WeightTuple{{6, 1}},
+		8: []WeightTuple{{2, 2}},
 	}
 
 	if !reflect.DeepEqual(mst.AdjacencyList, expected) {
 		t.Errorf("expected %v, got %v", expected, mst.AdjacencyList)
 	}
 }
+
+func TestPrimDirected(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 0, 1)
+
+	_, err := g.Prim()
+	if err == nil {
+		t.Errorf("expected an error for a directed graph")
+	}
+}
+
+func TestPrimForest(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 3)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(5, 6, 4)
+	g.AddNode(9)
+
+	mst, err := g.Prim()
+	if err != nil {
+		t.Error(err)
+	}
+	expect := AdjList{
+		0: []WeightTuple{{2, 2}},
+		1: []WeightTuple{{2, 1}},
+		2: []WeightTuple{{1, 1}, {0, 2}},
+		5: []WeightTuple{{6, 4}},
+		6: []WeightTuple{{5, 4}},
+		9: []WeightTuple{},
+	}
+	if !reflect.DeepEqual(mst.AdjacencyList, expect) {
+		t.Errorf("expected %v, got %v", expect, mst.AdjacencyList)
+	}
+}
+
+func TestPrimQueues(t *testing.T) {
+	g := NewUndirectedGraphOf[int, int]()
+	rnd := rand.New(rand.NewSource(4))
+	for i := 0; i < 2000; i++ {
+		g.AddEdge(rnd.Intn(300), rnd.Intn(300), rnd.Intn(50))
+	}
+	weight := func(mst *GraphOf[int, int]) int {
+		sum := 0
+		for _, edges := range mst.AdjacencyList {
+			for _, e := range edges {
+				sum += e.Weight
+			}
+		}
+		return sum / 2
+	}
+	mst, err := g.Prim()
+	if err != nil {
+		t.Error(err)
+	}
+	expect := weight(mst)
+
+	// the radix heap is monotone, but Prim's keys are not popped in increasing order
+	queues := map[string]func(n int) pq.Queue[int]{
+		"4-ary":   testQueues["4-ary"],
+		"pairing": testQueues["pairing"],
+	}
+	for name, queue := range queues {
+		mst, err := g.PrimWithQueue(queue)
+		if err != nil {
+			t.Error(err)
+		}
+		if w := weight(mst); w != expect {
+			t.Errorf("%s: expected a spanning forest of weight %d, got %d", name, expect, w)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/timHau/graph/pq"
+)
+
+// MultiSourceTreeOf is a shortest path forest rooted at several sources. Every node belongs to the
+// tree of its nearest source, DistTo is the distance between the node and that source and PathTo
+// the path between them, in the direction of the search. Source is the first of the sources.
+// After MultiSourceDijkstraTo, Predecessors maps every node to the next node on its path to the source.
+type MultiSourceTreeOf[N comparable, W Number] struct {
+	*ShortestPathTreeOf[N, W]
+	origin []int // dense index of the nearest source, -1 for nodes that were not reached
+	// toSources is set for searches on the transposed graph, then pre points towards the source
+	toSources bool
+}
+
+type MultiSourceTree = MultiSourceTreeOf[int, float64]
+
+// MultiSourceDijkstra runs Dijkstra from all sources at once, like from a virtual node with
+// an arc of weight 0 to each of them. It finds the distance from the nearest source to every
+// node in a single run, e.g. how fast the nearest fire station reaches each address.
+// On directed graphs this differs from the distance of every node to its nearest source,
+// which MultiSourceDijkstraTo computes.
+//
+// If targets are given the search stops as soon as all of them are settled. Only nodes settled
+// until then are part of the result, all others are reported as not reachable.
+//
+// Time Complexity: O((V + E) log V)
+// Space Complexity: O(V)
+func MultiSourceDijkstra[N comparable, W Number](g Reader[N, W], sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return multiSourceDijkstra(g, sources, targets, false)
+}
+
+// MultiSourceDijkstraTo is MultiSourceDijkstra in the other direction: it finds the distance from
+// every node to its nearest source, e.g. from every address to the nearest hospital, by searching
+// backwards along the arcs. PathTo(v) returns the path from v to its nearest source.
+//
+// Time Complexity: O((V + E) log V)
+// Space Complexity: O(V + E) for the transposed graph of a directed graph
+func MultiSourceDijkstraTo[N comparable, W Number](g Reader[N, W], sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return multiSourceDijkstra(g, sources, targets, true)
+}
+
+func multiSourceDijkstra[N comparable, W Number](g Reader[N, W], sources, targets []N, toSources bool) (*MultiSourceTreeOf[N, W], error) {
+	d := viewOf(g)
+	if hasNegativeArcs(d) {
+		return nil, errors.New("dijkstras Algorithm does not support negative edge weights")
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("at least one source is required")
+	}
+
+	dense := make([]int, len(sources))
+	for i, v := range sources {
+		s, ok := d.index(v)
+		if !ok {
+			return nil, errors.New("source node is not part of the graph")
+		}
+		dense[i] = s
+	}
+	// remaining counts the targets that are not settled yet
+	isTarget := make([]bool, d.len())
+	remaining := 0
+	for _, v := range targets {
+		t, ok := d.index(v)
+		if !ok {
+			return nil, errors.New("target node is not part of the graph")
+		}
+		if !isTarget[t] {
+			isTarget[t] = true
+			remaining++
+		}
+	}
+
+	tree := newShortestPathTree(d, dense[0])
+	res := &MultiSourceTreeOf[N, W]{tree, make([]int, d.len()), toSources}
+	for i := range res.origin {
+		res.origin[i] = -1
+	}
+	for _, s := range dense {
+		tree.dist[s] = 0
+		res.origin[s] = s
+	}
+
+	// undirected graphs are their own transpose
+	search := d
+	if toSources && g.IsDirected() {
+		search = transposeOf(d)
+	}
+	settled := make([]bool, d.len())
+	dijkstraLoop[N, W](search, tree, dense, pq.NewMin[W](d.len()), func(u int) bool {
+		settled[u] = true
+		// the predecessor is settled first, so its source is known
+		if tree.pre[u] != -1 {
+			res.origin[u] = res.origin[tree.pre[u]]
+		}
+		if isTarget[u] {
+			remaining--
+			return remaining == 0
+		}
+		return false
+	})
+
+	// the distances of nodes that were reached but not settled are not final
+	for v, ok := range settled {
+		if !ok {
+			tree.dist[v] = infinity[W]()
+			tree.pre[v] = -1
+			res.origin[v] = -1
+		}
+	}
+	return res, nil
+}
+
+// PathTo returns the nodes on the shortest path between v and its nearest source and its length,
+// from the source to v for MultiSourceDijkstra and from v to the source for MultiSourceDijkstraTo.
+// It reports false if v was not reached.
+func (t *MultiSourceTreeOf[N, W]) PathTo(v N) ([]N, W, bool) {
+	path, cost, ok := t.ShortestPathTreeOf.PathTo(v)
+	if ok && t.toSources {
+		for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+			path[l], path[r] = path[r], path[l]
+		}
+	}
+	return path, cost, ok
+}
+
+// NearestSource returns the source closest to v, or false if v was not reached
+func (t *MultiSourceTreeOf[N, W]) NearestSource(v N) (N, bool) {
+	i, ok := t.nodes.index(v)
+	if !ok || t.origin[i] == -1 {
+		var none N
+		return none, false
+	}
+	return t.nodes.node(t.origin[i]), true
+}
+
+// NearestSources returns the nearest source of every node that was reached
+func (t *MultiSourceTreeOf[N, W]) NearestSources() map[N]N {
+	res := make(map[N]N, len(t.origin))
+	for i, s := range t.origin {
+		if s != -1 {
+			res[t.nodes.node(i)] = t.nodes.node(s)
+		}
+	}
+	return res
+}
+
+func (g *GraphOf[N, W]) MultiSourceDijkstra(sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return MultiSourceDijkstra[N, W](g, sources, targets)
+}
+
+func (g *GraphOf[N, W]) MultiSourceDijkstraTo(sources, targets []N) (*MultiSourceTreeOf[N, W], error) {
+	return MultiSourceDijkstraTo[N, W](g, sources, targets)
+}
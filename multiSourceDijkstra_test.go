@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Example Graph:
+// ┌─────┐   2   ┌─────┐   3   ┌─────┐   1   ┌─────┐   4   ┌─────┐
+// │  0  ├───────┤  1  ├───────┤  2  ├───────┤  3  ├───────┤  4  │
+// └─────┘       └─────┘       └─────┘       └─────┘       └─────┘
+func TestMultiSourceDijkstra(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 4)
+	g.AddNode(5)
+
+	tree, err := g.MultiSourceDijkstra([]int{0, 4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectDist := map[int]float64{0: 0, 1: 2, 2: 5, 3: 4, 4: 0, 5: infinity[float64]()}
+	if !reflect.DeepEqual(tree.Distances(), expectDist) {
+		t.Errorf("expected distances %v, got %v", expectDist, tree.Distances())
+	}
+	expectSources := map[int]int{0: 0, 1: 0, 2: 0, 3: 4, 4: 4}
+	if !reflect.DeepEqual(tree.NearestSources(), expectSources) {
+		t.Errorf("expected nearest sources %v, got %v", expectSources, tree.NearestSources())
+	}
+	if path, cost, _ := tree.PathTo(3); !reflect.DeepEqual(path, []int{4, 3}) || cost != 4 {
+		t.Errorf("expected the path [4 3] with cost 4, got %v with cost %v", path, cost)
+	}
+	if _, ok := tree.NearestSource(5); ok {
+		t.Errorf("expected 5 to have no nearest source")
+	}
+}
+
+func TestMultiSourceDijkstraTargets(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 4)
+
+	// 1 and 3 are settled before 2, which is the farthest node
+	tree, err := g.MultiSourceDijkstra([]int{0, 4}, []int{1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.DistTo(1) != 2 || tree.DistTo(3) != 4 {
+		t.Errorf("expected exact distances for the targets, got %v", tree.Distances())
+	}
+	if tree.Reachable(2) {
+		t.Errorf("expected the search to stop before 2 is settled")
+	}
+	if s, ok := tree.NearestSource(3); !ok || s != 4 {
+		t.Errorf("expected 4 to be the nearest source of 3, got %v", s)
+	}
+
+	if _, err := g.MultiSourceDijkstra(nil, nil); err == nil {
+		t.Errorf("expected an error without sources")
+	}
+	if _, err := g.MultiSourceDijkstra([]int{0}, []int{9}); err == nil {
+		t.Errorf("expected an error for an unknown target")
+	}
+}
+
+// Example Graph:
+// ┌─────┐  1  ┌─────┐  1  ┌─────┐  1  ┌─────┐  1  ┌─────┐
+// │  0  ├────►│  1  ├────►│  2  ├────►│  3  ├────►│  4  │
+// └──▲──┘     └─────┘     └─────┘     └─────┘     └──┬──┘
+// .  │                      1                        │
+// .  └───────────────────────────────────────────────┘
+func TestMultiSourceDijkstraDirection(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 0, 1)
+
+	// from the nearest source to every node
+	from, err := g.MultiSourceDijkstra([]int{0, 4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := from.NearestSource(3); s != 0 || from.DistTo(3) != 3 {
+		t.Errorf("expected 3 to be reached from 0 with distance 3, got %v with distance %v", s, from.DistTo(3))
+	}
+
+	// from every node to the nearest source
+	to, err := g.MultiSourceDijkstraTo([]int{0, 4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := to.NearestSource(3); s != 4 || to.DistTo(3) != 1 {
+		t.Errorf("expected 3 to reach 4 with distance 1, got %v with distance %v", s, to.DistTo(3))
+	}
+	if path, cost, _ := to.PathTo(1); !reflect.DeepEqual(path, []int{1, 2, 3, 4}) || cost != 3 {
+		t.Errorf("expected the path [1 2 3 4] with cost 3, got %v with cost %v", path, cost)
+	}
+	if path, _, _ := from.PathTo(1); !reflect.DeepEqual(path, []int{0, 1}) {
+		t.Errorf("expected the path [0 1], got %v", path)
+	}
+}
+
+func TestMultiSourceDijkstraMatchesDijkstra(t *testing.T) {
+	g := randomGraph(200, 800, 4, false, 1000000)
+	sources := []int{3, 50, 120, 199}
+	tree, err := g.MultiSourceDijkstra(sources, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the distance to the nearest source is the minimum over single source runs
+	best := make(map[int]float64)
+	for _, s := range sources {
+		single, err := g.Dijkstra(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for v, dist := range single.Distances() {
+			if old, ok := best[v]; !ok || dist < old {
+				best[v] = dist
+			}
+		}
+	}
+	if !reflect.DeepEqual(tree.Distances(), best) {
+		t.Errorf("expected the distances to the nearest source")
+	}
+	for v := 0; v < 200; v++ {
+		path, cost, ok := tree.PathTo(v)
+		if !ok {
+			continue
+		}
+		if s, _ := tree.NearestSource(v); path[0] != s || cost != best[v] {
+			t.Errorf("expected a path from the nearest source %v to %d, got %v", s, v, path)
+		}
+	}
+
+	// stopping early keeps the distances of all targets exact
+	targets := []int{7, 77, 177}
+	partial, err := g.MultiSourceDijkstra(sources, targets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range targets {
+		if partial.DistTo(v) != best[v] {
+			t.Errorf("expected distance %v to %d, got %v", best[v], v, partial.DistTo(v))
+		}
+	}
+}
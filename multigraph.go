@@ -0,0 +1,89 @@
+package graph
+
+// EdgeID identifies an edge. Ids are stable, they do not change when other edges are removed.
+type EdgeID int
+
+// MultiEdgeOf is an edge together with its id
+type MultiEdgeOf[N comparable, W Number] struct {
+	ID EdgeID
+	EdgeOf[N, W]
+}
+
+type MultiEdge = MultiEdgeOf[int, float64]
+
+// NewMultiGraph creates a directed graph that allows parallel edges between two nodes
+func NewMultiGraph() *Graph {
+	return NewMultiGraphOf[int, float64]()
+}
+
+func NewMultiGraphOf[N comparable, W Number]() *GraphOf[N, W] {
+	g := NewGraphOf[N, W]()
+	g.multi = true
+	return g
+}
+
+// NewUndirectedMultiGraph creates an undirected graph that allows parallel edges between two nodes
+func NewUndirectedMultiGraph() *Graph {
+	return NewUndirectedMultiGraphOf[int, float64]()
+}
+
+func NewUndirectedMultiGraphOf[N comparable, W Number]() *GraphOf[N, W] {
+	g := NewUndirectedGraphOf[N, W]()
+	g.multi = true
+	return g
+}
+
+// IsMultigraph reports whether the graph allows parallel edges
+func (g *GraphOf[N, W]) IsMultigraph() bool {
+	return g.multi
+}
+
+// EdgesBetween returns all edges from u to v, in the order they were added
+func (g *GraphOf[N, W]) EdgesBetween(u, v N) []MultiEdgeOf[N, W] {
+	edges := make([]MultiEdgeOf[N, W], 0)
+	for i, e := range g.AdjacencyList[u] {
+		if e.To == v {
+			edges = append(edges, MultiEdgeOf[N, W]{g.edgeIDs[u][i], EdgeOf[N, W]{u, v, e.Weight}})
+		}
+	}
+	return edges
+}
+
+// EdgeByID returns the edge with the given id, or nil if there is none
+func (g *GraphOf[N, W]) EdgeByID(id EdgeID) *EdgeOf[N, W] {
+	ends, ok := g.edgeEnds[id]
+	if !ok {
+		return nil
+	}
+	for i, arcID := range g.edgeIDs[ends[0]] {
+		if arcID == id {
+			return &EdgeOf[N, W]{ends[0], ends[1], g.AdjacencyList[ends[0]][i].Weight}
+		}
+	}
+	return nil
+}
+
+// RemoveEdgeByID removes the edge with the given id, leaving parallel edges untouched
+func (g *GraphOf[N, W]) RemoveEdgeByID(id EdgeID) {
+	ends, ok := g.edgeEnds[id]
+	if !ok {
+		return
+	}
+	drop := func(_ N, arcID EdgeID) bool { return arcID == id }
+	g.removeArcs(ends[0], drop)
+	if g.undirected && ends[0] != ends[1] {
+		g.removeArcs(ends[1], drop)
+	}
+}
+
+// UpdateEdgeByID sets the weight of the edge with the given id
+func (g *GraphOf[N, W]) UpdateEdgeByID(id EdgeID, weight W) {
+	ends, ok := g.edgeEnds[id]
+	if !ok {
+		return
+	}
+	g.updateArc(ends[0], id, weight)
+	if g.undirected && ends[0] != ends[1] {
+		g.updateArc(ends[1], id, weight)
+	}
+}
@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiGraph(t *testing.T) {
+	g := NewMultiGraph()
+	a := g.AddEdge(0, 1, 5)
+	b := g.AddEdge(0, 1, 3)
+	c := g.AddEdge(1, 2, 1)
+
+	if g.NumEdges() != 3 {
+		t.Errorf("expected 3 edges, got %d", g.NumEdges())
+	}
+
+	expected := []MultiEdge{
+		{a, Edge{0, 1, 5}},
+		{b, Edge{0, 1, 3}},
+	}
+	if !reflect.DeepEqual(g.EdgesBetween(0, 1), expected) {
+		t.Errorf("expected %v, got %v", expected, g.EdgesBetween(0, 1))
+	}
+
+	g.UpdateEdgeByID(a, 2)
+	if g.EdgeByID(a).Weight != 2 || g.EdgeByID(b).Weight != 3 {
+		t.Errorf("expected only edge %d to be updated, got %v", a, g.EdgesBetween(0, 1))
+	}
+
+	g.RemoveEdgeByID(a)
+	expected = []MultiEdge{{b, Edge{0, 1, 3}}}
+	if !reflect.DeepEqual(g.EdgesBetween(0, 1), expected) {
+		t.Errorf("expected %v, got %v", expected, g.EdgesBetween(0, 1))
+	}
+	if g.EdgeByID(c) == nil {
+		t.Errorf("expected edge %d to still exist", c)
+	}
+
+	g.RemoveNode(1)
+	if g.NumEdges() != 0 || g.EdgeByID(b) != nil || g.EdgeByID(c) != nil {
+		t.Errorf("expected all edges to be removed together with node 1")
+	}
+}
+
+func TestUndirectedMultiGraph(t *testing.T) {
+	g := NewUndirectedMultiGraph()
+	a := g.AddEdge(0, 1, 5)
+	b := g.AddEdge(1, 0, 3)
+
+	if g.NumEdges() != 2 {
+		t.Errorf("expected 2 edges, got %d", g.NumEdges())
+	}
+	if len(g.EdgesBetween(1, 0)) != 2 {
+		t.Errorf("expected 2 edges between 1 and 0, got %v", g.EdgesBetween(1, 0))
+	}
+
+	g.UpdateEdgeByID(b, 1)
+	expected := []MultiEdge{{a, Edge{0, 1, 5}}, {b, Edge{0, 1, 1}}}
+	if !reflect.DeepEqual(g.EdgesBetween(0, 1), expected) {
+		t.Errorf("expected %v, got %v", expected, g.EdgesBetween(0, 1))
+	}
+
+	g.RemoveEdgeByID(a)
+	expected = []MultiEdge{{b, Edge{1, 0, 1}}}
+	if !reflect.DeepEqual(g.EdgesBetween(1, 0), expected) {
+		t.Errorf("expected %v, got %v", expected, g.EdgesBetween(1, 0))
+	}
+}
+
+func TestMultiGraphShortestPaths(t *testing.T) {
+	g := NewMultiGraph()
+	g.AddEdge(0, 1, 7)
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 4)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(0, 2, 9)
+
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+	dist := tree.Distances()
+	expectDist := map[int]float64{0: 0, 1: 2, 2: 3}
+	if !reflect.DeepEqual(dist, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, dist)
+	}
+
+	tree, err = g.BellmanFord(0)
+	if err != nil {
+		t.Error(err)
+	}
+	bf := tree.Distances()
+	if !reflect.DeepEqual(bf, expectDist) {
+		t.Errorf("expected: %v, got: %v", expectDist, bf)
+	}
+
+	m := g.FloydWarshall().Matrix()
+	if m[1] != 2 || m[2] != 3 || m[5] != 1 {
+		t.Errorf("expected the lightest parallel edges to be used, got %v", m)
+	}
+}
+
+// Example Graph:
+// .          10
+// .  ┌──────────────────┐
+// .  │                  ▼
+// ┌──┴──┐     4      ┌─────┐
+// │  0  ├───────────►│  1  │
+// └─────┘            └─────┘
+func TestMultiGraphPaths(t *testing.T) {
+	g := NewMultiGraph()
+	g.AddEdge(0, 1, 10)
+	g.AddEdge(0, 1, 4)
+
+	// paths only hold nodes, so the parallel edges give two paths with the same nodes
+	expect := []Path{{[]int{0, 1}, 4}, {[]int{0, 1}, 10}}
+	paths, err := g.EdgeDisjointPaths(0, 1, 2)
+	if err != nil || !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v, %v", expect, paths, err)
+	}
+	paths, err = g.NodeDisjointPaths(0, 1, 2)
+	if err != nil || !reflect.DeepEqual(paths, expect) {
+		t.Errorf("expected %v, got %v, %v", expect, paths, err)
+	}
+
+	// the k shortest paths are distinct node sequences, the heavier edge is never used
+	paths, err = g.KShortestPaths(0, 1, 2)
+	if err != nil || !reflect.DeepEqual(paths, expect[:1]) {
+		t.Errorf("expected %v, got %v, %v", expect[:1], paths, err)
+	}
+}
@@ -0,0 +1,51 @@
+package graph
+
+import "fmt"
+
+// NegativeCycleError is returned when a graph contains a cycle with negative total weight,
+// so some shortest paths are not defined. Cycle lists the nodes in the order of its arcs,
+// the last node has an arc back to the first one.
+type NegativeCycleError[N comparable, W Number] struct {
+	Cycle  []N
+	Weight W
+}
+
+func (e *NegativeCycleError[N, W]) Error() string {
+	return fmt.Sprintf("Graph contains negative cycle %v with total weight %v", e.Cycle, e.Weight)
+}
+
+// negativeCycle extracts a negative cycle from the predecessors left by Bellman-Ford,
+// starting at node x that was still relaxed in the last round.
+func negativeCycle[N comparable, W Number](d denseView[N, W], pre []int, x int) *NegativeCycleError[N, W] {
+	// x may only be reachable from the cycle, after V steps back we are on it for sure
+	for i := 0; i < d.len() && pre[x] != -1; i++ {
+		x = pre[x]
+	}
+	cycle := []int{x}
+	for v := pre[x]; v != x && v != -1; v = pre[v] {
+		cycle = append(cycle, v)
+	}
+	// the cycle was collected backwards
+	for l, r := 0, len(cycle)-1; l < r; l, r = l+1, r-1 {
+		cycle[l], cycle[r] = cycle[r], cycle[l]
+	}
+
+	err := &NegativeCycleError[N, W]{Cycle: make([]N, len(cycle))}
+	for i, v := range cycle {
+		err.Cycle[i] = d.node(v)
+		err.Weight += lightestArc(d, v, cycle[(i+1)%len(cycle)])
+	}
+	return err
+}
+
+// lightestArc returns the smallest weight of the arcs from u to v
+func lightestArc[N comparable, W Number](d denseView[N, W], u, v int) W {
+	res := infinity[W]()
+	targets, weights := d.arcs(u)
+	for j, to := range targets {
+		if to == v && weights[j] < res {
+			res = weights[j]
+		}
+	}
+	return res
+}
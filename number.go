@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Number is the constraint for edge weights
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// infinity returns +Inf for floating point weights and the largest
+// representable value for integer weights. It is used as the distance of
+// unreachable nodes.
+func infinity[W Number]() W {
+	var w W
+	v := reflect.ValueOf(&w).Elem()
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(math.Inf(1))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(1)<<(v.Type().Bits()-1) - 1)
+	default:
+		v.SetUint(math.MaxUint64 >> (64 - v.Type().Bits()))
+	}
+	return w
+}
+
+// negativeInfinity returns -Inf for floating point weights and the smallest
+// representable value for signed integer weights. It is used as the distance of
+// nodes that can be reached through a negative cycle.
+func negativeInfinity[W Number]() W {
+	var w W
+	v := reflect.ValueOf(&w).Elem()
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(math.Inf(-1))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(-1 << (v.Type().Bits() - 1))
+	}
+	// unsigned weights can not form negative cycles
+	return w
+}
+
+// sortNodes sorts nodes by their natural order if their underlying type is ordered
+// (integers, floats and strings) and reports whether it did.
+func sortNodes[N comparable](nodes []N) bool {
+	switch s := any(nodes).(type) {
+	case []int:
+		sort.Ints(s)
+		return true
+	case []string:
+		sort.Strings(s)
+		return true
+	case []float64:
+		sort.Float64s(s)
+		return true
+	}
+
+	// other ordered types are converted to a key once per node, not once per comparison
+	switch reflect.TypeOf((*N)(nil)).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sortByKey(nodes, func(v N) int64 { return reflect.ValueOf(v).Int() })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sortByKey(nodes, func(v N) uint64 { return reflect.ValueOf(v).Uint() })
+	case reflect.Float32, reflect.Float64:
+		sortByKey(nodes, func(v N) float64 { return reflect.ValueOf(v).Float() })
+	case reflect.String:
+		sortByKey(nodes, func(v N) string { return reflect.ValueOf(v).String() })
+	default:
+		return false
+	}
+	return true
+}
+
+// byKey sorts nodes and their keys together
+type byKey[N any, K Number | ~string] struct {
+	nodes []N
+	keys  []K
+}
+
+func (b byKey[N, K]) Len() int           { return len(b.nodes) }
+func (b byKey[N, K]) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey[N, K]) Swap(i, j int) {
+	b.nodes[i], b.nodes[j] = b.nodes[j], b.nodes[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+func sortByKey[N any, K Number | ~string](nodes []N, key func(N) K) {
+	keys := make([]K, len(nodes))
+	for i, v := range nodes {
+		keys[i] = key(v)
+	}
+	sort.Sort(byKey[N, K]{nodes, keys})
+}
@@ -0,0 +1,55 @@
+package graph
+
+// PageRank computes the PageRank of every node with the power iteration method.
+// Every arc counts as one link regardless of its weight, the rank of nodes without
+// outgoing arcs is distributed evenly over all nodes.
+//
+// damping is the probability of following a link (usually 0.85)
+//
+// Time Complexity: O(iterations * (V + E))
+func PageRank[N comparable, W Number](g Reader[N, W], damping float64, iterations int) map[N]float64 {
+	d := viewOf(g)
+	numNodes := d.len()
+	res := make(map[N]float64, numNodes)
+	if numNodes == 0 {
+		return res
+	}
+
+	rank := make([]float64, numNodes)
+	for i := range rank {
+		rank[i] = 1 / float64(numNodes)
+	}
+
+	next := make([]float64, numNodes)
+	for it := 0; it < iterations; it++ {
+		dangling := 0.0
+		for i := range next {
+			next[i] = 0
+		}
+		for i := 0; i < numNodes; i++ {
+			targets, _ := d.arcs(i)
+			if len(targets) == 0 {
+				dangling += rank[i]
+				continue
+			}
+			share := rank[i] / float64(len(targets))
+			for _, to := range targets {
+				next[to] += share
+			}
+		}
+		base := (1-damping)/float64(numNodes) + damping*dangling/float64(numNodes)
+		for i := range next {
+			next[i] = base + damping*next[i]
+		}
+		rank, next = next, rank
+	}
+
+	for i, r := range rank {
+		res[d.node(i)] = r
+	}
+	return res
+}
+
+func (g *GraphOf[N, W]) PageRank(damping float64, iterations int) map[N]float64 {
+	return PageRank[N, W](g, damping, iterations)
+}
@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPageRankCycle(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+
+	rank := g.PageRank(0.85, 50)
+	for v, r := range rank {
+		if math.Abs(r-1.0/3) > 1e-9 {
+			t.Errorf("expected rank 1/3 for node %d, got %f", v, r)
+		}
+	}
+}
+
+// Example Graph:
+// ┌─────┐         ┌─────┐
+// │  0  ├────────►│  1  │
+// └──┬──┘         └──┬──┘
+// .  │               │
+// .  │               ▼
+// .  │            ┌─────┐
+// .  └───────────►│  2  │
+// .               └─────┘
+func TestPageRankDangling(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 2, 1)
+
+	rank := g.PageRank(0.85, 100)
+	sum := 0.0
+	for _, r := range rank {
+		sum += r
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("expected ranks to sum up to 1, got %f", sum)
+	}
+	if !(rank[2] > rank[1] && rank[1] > rank[0]) {
+		t.Errorf("expected rank(2) > rank(1) > rank(0), got %v", rank)
+	}
+}
@@ -0,0 +1,121 @@
+package pq
+
+// DAry is an implicit heap in which every node has d children.
+// Push and Update in O(log_d n), Pop in O(d log_d n). A larger d makes the heap shallower,
+// which pays off when there are many more priority changes than pops, like Dijkstra on dense graphs.
+type DAry[P Ordered] struct {
+	d    int
+	less func(a, b P) bool
+	heap []int // items in heap order
+	prio []P   // priority of every item
+	pos  []int // position of every item in heap, -1 if it is not queued
+}
+
+// NewDAry returns an empty d-ary heap for the items 0..n-1, ordered by less.
+// Items with equal priorities are popped in increasing order.
+func NewDAry[P Ordered](n, d int, less func(a, b P) bool) *DAry[P] {
+	if d < 2 {
+		panic("pq: a d-ary heap needs d >= 2")
+	}
+	q := &DAry[P]{
+		d:    d,
+		less: less,
+		heap: make([]int, 0),
+		prio: make([]P, n),
+		pos:  make([]int, n),
+	}
+	for i := range q.pos {
+		q.pos[i] = -1
+	}
+	return q
+}
+
+func (q *DAry[P]) Len() int { return len(q.heap) }
+
+func (q *DAry[P]) Contains(item int) bool {
+	return q.pos[item] != -1
+}
+
+func (q *DAry[P]) Prio(item int) P {
+	return q.prio[item]
+}
+
+func (q *DAry[P]) Push(item int, prio P) {
+	if q.Contains(item) {
+		q.Update(item, prio)
+		return
+	}
+	q.prio[item] = prio
+	q.pos[item] = len(q.heap)
+	q.heap = append(q.heap, item)
+	q.up(len(q.heap) - 1)
+}
+
+func (q *DAry[P]) Update(item int, prio P) {
+	q.prio[item] = prio
+	// only one of them moves the item
+	q.up(q.pos[item])
+	q.down(q.pos[item])
+}
+
+func (q *DAry[P]) Peek() (int, P) {
+	return q.heap[0], q.prio[q.heap[0]]
+}
+
+func (q *DAry[P]) Pop() (int, P) {
+	item := q.heap[0]
+	last := len(q.heap) - 1
+	q.swap(0, last)
+	q.heap = q.heap[:last]
+	q.pos[item] = -1
+	if last > 0 {
+		q.down(0)
+	}
+	return item, q.prio[item]
+}
+
+// before orders by priority first and breaks ties by the smaller item, so pops are deterministic
+func (q *DAry[P]) before(i, j int) bool {
+	a, b := q.heap[i], q.heap[j]
+	if q.less(q.prio[a], q.prio[b]) {
+		return true
+	}
+	if q.less(q.prio[b], q.prio[a]) {
+		return false
+	}
+	return a < b
+}
+
+func (q *DAry[P]) swap(i, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.pos[q.heap[i]] = i
+	q.pos[q.heap[j]] = j
+}
+
+func (q *DAry[P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / q.d
+		if !q.before(i, parent) {
+			break
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+func (q *DAry[P]) down(i int) {
+	n := len(q.heap)
+	for {
+		first := i
+		for c := q.d*i + 1; c <= q.d*i+q.d && c < n; c++ {
+			if q.before(c, first) {
+				first = c
+			}
+		}
+		if first == i {
+			return
+		}
+		q.swap(i, first)
+		i = first
+	}
+}
@@ -0,0 +1,153 @@
+package pq
+
+// Pairing is a pairing heap. Push and moving an item to the front are O(1),
+// Pop is O(log n) amortized. It is usually the fastest choice when most updates
+// improve priorities, which is the case for Dijkstra, Prim and A*.
+type Pairing[P Ordered] struct {
+	less  func(a, b P) bool
+	nodes []pairingNode[P]
+	root  int
+	size  int
+	queue []bool
+	// scratch space for merging the children of a removed node
+	pairs []int
+}
+
+// pairingNode links an item into the heap, -1 marks a missing link.
+// prev is the parent for the first child and the left sibling for all others.
+type pairingNode[P Ordered] struct {
+	prio                 P
+	child, sibling, prev int
+}
+
+// NewPairing returns an empty pairing heap for the items 0..n-1, ordered by less
+func NewPairing[P Ordered](n int, less func(a, b P) bool) *Pairing[P] {
+	return &Pairing[P]{
+		less:  less,
+		nodes: make([]pairingNode[P], n),
+		root:  -1,
+		queue: make([]bool, n),
+		pairs: make([]int, 0),
+	}
+}
+
+func (q *Pairing[P]) Len() int { return q.size }
+
+func (q *Pairing[P]) Contains(item int) bool {
+	return q.queue[item]
+}
+
+func (q *Pairing[P]) Prio(item int) P {
+	return q.nodes[item].prio
+}
+
+func (q *Pairing[P]) Push(item int, prio P) {
+	if q.Contains(item) {
+		q.Update(item, prio)
+		return
+	}
+	q.nodes[item] = pairingNode[P]{prio: prio, child: -1, sibling: -1, prev: -1}
+	q.queue[item] = true
+	q.size++
+	q.root = q.meld(q.root, item)
+}
+
+func (q *Pairing[P]) Update(item int, prio P) {
+	old := q.nodes[item].prio
+	q.nodes[item].prio = prio
+	if item == q.root {
+		if q.less(old, prio) {
+			// the root got worse, its children may have to move up
+			q.root = q.meld(q.mergePairs(q.detachChildren(item)), item)
+		}
+		return
+	}
+	q.cut(item)
+	if !q.less(prio, old) {
+		// the item got worse, its children may have to move up
+		q.root = q.meld(q.root, q.mergePairs(q.detachChildren(item)))
+	}
+	q.root = q.meld(q.root, item)
+}
+
+func (q *Pairing[P]) Peek() (int, P) {
+	return q.root, q.nodes[q.root].prio
+}
+
+func (q *Pairing[P]) Pop() (int, P) {
+	item := q.root
+	q.root = q.mergePairs(q.detachChildren(item))
+	q.queue[item] = false
+	q.size--
+	return item, q.nodes[item].prio
+}
+
+// meld links two heap roots and returns the new root
+func (q *Pairing[P]) meld(a, b int) int {
+	if a == -1 {
+		return b
+	}
+	if b == -1 {
+		return a
+	}
+	if q.less(q.nodes[b].prio, q.nodes[a].prio) {
+		a, b = b, a
+	}
+	// b becomes the first child of a
+	first := q.nodes[a].child
+	q.nodes[b].sibling = first
+	if first != -1 {
+		q.nodes[first].prev = b
+	}
+	q.nodes[b].prev = a
+	q.nodes[a].child = b
+	q.nodes[a].sibling = -1
+	q.nodes[a].prev = -1
+	return a
+}
+
+// cut removes the subtree rooted at item from its parent
+func (q *Pairing[P]) cut(item int) {
+	n := &q.nodes[item]
+	if q.nodes[n.prev].child == item {
+		q.nodes[n.prev].child = n.sibling
+	} else {
+		q.nodes[n.prev].sibling = n.sibling
+	}
+	if n.sibling != -1 {
+		q.nodes[n.sibling].prev = n.prev
+	}
+	n.prev, n.sibling = -1, -1
+}
+
+// detachChildren removes all children of item and returns the first one
+func (q *Pairing[P]) detachChildren(item int) int {
+	first := q.nodes[item].child
+	q.nodes[item].child = -1
+	return first
+}
+
+// mergePairs melds a list of siblings into one heap with the standard two pass strategy:
+// meld pairs from left to right, then meld the results from right to left.
+func (q *Pairing[P]) mergePairs(first int) int {
+	q.pairs = q.pairs[:0]
+	for first != -1 {
+		a := first
+		b := q.nodes[a].sibling
+		if b == -1 {
+			first = -1
+		} else {
+			first = q.nodes[b].sibling
+		}
+		q.nodes[a].sibling, q.nodes[a].prev = -1, -1
+		if b != -1 {
+			q.nodes[b].sibling, q.nodes[b].prev = -1, -1
+		}
+		q.pairs = append(q.pairs, q.meld(a, b))
+	}
+	root := -1
+	for i := len(q.pairs) - 1; i >= 0; i-- {
+		root = q.meld(q.pairs[i], root)
+	}
+	return root
+}
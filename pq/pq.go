@@ -0,0 +1,53 @@
+// Package pq implements indexed priority queues over the items 0..n-1.
+//
+// Every queue keeps track of where its items are stored, so membership is O(1) and the
+// priority of a queued item can be changed without searching for it. This is what
+// Dijkstra, Prim and A* need: items are dense node indices, priorities are distances.
+package pq
+
+// Ordered is the constraint for priorities
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Integer is the constraint for priorities of a radix heap
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Queue is an indexed priority queue of the items 0..n-1.
+// Pop returns the item that comes first according to the order the queue was created with.
+type Queue[P Ordered] interface {
+	Len() int
+	// Contains reports whether item is currently queued
+	Contains(item int) bool
+	// Prio returns the priority item was last pushed or updated with
+	Prio(item int) P
+	// Push adds item with the given priority. If item is already queued its priority is updated instead.
+	Push(item int, prio P)
+	// Update changes the priority of a queued item
+	Update(item int, prio P)
+	// Peek returns the first item and its priority without removing it
+	Peek() (int, P)
+	// Pop removes and returns the first item and its priority
+	Pop() (int, P)
+}
+
+// Less orders priorities ascending, queues created with it are min queues
+func Less[P Ordered](a, b P) bool { return a < b }
+
+// Greater orders priorities descending, queues created with it are max queues
+func Greater[P Ordered](a, b P) bool { return a > b }
+
+// NewMin returns a binary min heap for the items 0..n-1
+func NewMin[P Ordered](n int) *DAry[P] {
+	return NewDAry(n, 2, Less[P])
+}
+
+// NewMax returns a binary max heap for the items 0..n-1
+func NewMax[P Ordered](n int) *DAry[P] {
+	return NewDAry(n, 2, Greater[P])
+}
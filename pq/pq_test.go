@@ -0,0 +1,123 @@
+package pq
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func minQueues(n int) map[string]Queue[int] {
+	return map[string]Queue[int]{
+		"binary":  NewMin[int](n),
+		"4-ary":   NewDAry(n, 4, Less[int]),
+		"pairing": NewPairing(n, Less[int]),
+		"radix":   NewRadix[int](n),
+	}
+}
+
+func TestQueues(t *testing.T) {
+	for name, q := range minQueues(5) {
+		q.Push(0, 4)
+		q.Push(1, 2)
+		q.Push(3, 7)
+		q.Push(4, 6)
+
+		if q.Contains(2) || !q.Contains(3) {
+			t.Errorf("%s: expected 3 to be queued and 2 not", name)
+		}
+
+		q.Update(3, 1)
+		q.Update(4, 9)
+		if q.Prio(3) != 1 {
+			t.Errorf("%s: expected 1, got %d", name, q.Prio(3))
+		}
+		if item, prio := q.Peek(); item != 3 || prio != 1 || q.Len() != 4 {
+			t.Errorf("%s: expected to peek at 3 with prio 1, got %d with %d", name, item, prio)
+		}
+
+		order := make([]int, 0)
+		for q.Len() > 0 {
+			item, _ := q.Pop()
+			order = append(order, item)
+		}
+		expect := []int{3, 1, 0, 4}
+		if !reflect.DeepEqual(order, expect) {
+			t.Errorf("%s: expected %v, got %v", name, expect, order)
+		}
+		if q.Contains(3) {
+			t.Errorf("%s: expected popped items to leave the queue", name)
+		}
+	}
+}
+
+func TestQueuesPushTwice(t *testing.T) {
+	for name, q := range minQueues(3) {
+		q.Push(2, 9)
+		q.Push(1, 5)
+		q.Push(2, 3)
+		if q.Len() != 2 {
+			t.Errorf("%s: expected 2, got %d", name, q.Len())
+		}
+		item, prio := q.Pop()
+		if item != 2 || prio != 3 {
+			t.Errorf("%s: expected item 2 with prio 3, got %d with %d", name, item, prio)
+		}
+	}
+}
+
+func TestMaxQueues(t *testing.T) {
+	queues := map[string]Queue[float64]{
+		"binary":  NewMax[float64](4),
+		"pairing": NewPairing(4, Greater[float64]),
+	}
+	for name, q := range queues {
+		q.Push(0, 1.5)
+		q.Push(1, 3)
+		q.Push(2, 2)
+		q.Update(0, 4)
+
+		order := make([]int, 0)
+		for q.Len() > 0 {
+			item, _ := q.Pop()
+			order = append(order, item)
+		}
+		expect := []int{0, 1, 2}
+		if !reflect.DeepEqual(order, expect) {
+			t.Errorf("%s: expected %v, got %v", name, expect, order)
+		}
+	}
+}
+
+// TestQueuesRandom runs the same monotone sequence of pushes, updates and pops
+// on every queue and checks every pop against the smallest queued priority
+func TestQueuesRandom(t *testing.T) {
+	const n = 500
+	for name, q := range minQueues(n) {
+		rnd := rand.New(rand.NewSource(1))
+		prios := make(map[int]int)
+		last := 0
+		for step := 0; step < 20000; step++ {
+			if rnd.Intn(3) == 0 && q.Len() > 0 {
+				item, prio := q.Pop()
+				smallest := prio
+				for _, p := range prios {
+					if p < smallest {
+						smallest = p
+					}
+				}
+				if prio != prios[item] || prio != smallest {
+					t.Errorf("%s: popped %d with prio %d, expected prio %d", name, item, prio, smallest)
+				}
+				delete(prios, item)
+				last = prio
+			} else {
+				item, prio := rnd.Intn(n), last+rnd.Intn(1000)
+				q.Push(item, prio)
+				prios[item] = prio
+			}
+			if q.Len() != len(prios) {
+				t.Errorf("%s: expected %d queued items, got %d", name, len(prios), q.Len())
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package pq
+
+import "math/bits"
+
+// Radix is a monotone min queue for integer priorities: after an item with priority p was popped
+// or peeked, no item may be pushed or updated to a priority smaller than p. Dijkstra with non negative
+// integer weights satisfies this. Every item moves down through at most 65 buckets,
+// so Pop is O(log C) amortized where C is the largest priority, Push and Update are O(1).
+type Radix[P Integer] struct {
+	last    uint64 // key of the last popped item
+	size    int
+	buckets [65][]int
+	prio    []P
+	key     []uint64 // order preserving unsigned key of every priority
+	bucket  []int    // bucket of every item, -1 if it is not queued
+	pos     []int    // position of every item in its bucket
+	signed  bool
+}
+
+// NewRadix returns an empty radix heap for the items 0..n-1
+func NewRadix[P Integer](n int) *Radix[P] {
+	var zero P
+	q := &Radix[P]{
+		prio:   make([]P, n),
+		key:    make([]uint64, n),
+		bucket: make([]int, n),
+		pos:    make([]int, n),
+		signed: zero-1 < zero,
+	}
+	for i := range q.bucket {
+		q.bucket[i] = -1
+	}
+	if q.signed {
+		// the smallest signed priority maps to 0
+		q.last = q.keyOf(minSigned[P]())
+	}
+	return q
+}
+
+func (q *Radix[P]) Len() int { return q.size }
+
+func (q *Radix[P]) Contains(item int) bool {
+	return q.bucket[item] != -1
+}
+
+func (q *Radix[P]) Prio(item int) P {
+	return q.prio[item]
+}
+
+// Push panics if prio is smaller than the priority of the last popped or peeked item
+func (q *Radix[P]) Push(item int, prio P) {
+	if q.Contains(item) {
+		q.Update(item, prio)
+		return
+	}
+	q.check(prio)
+	q.size++
+	q.insert(item, prio)
+}
+
+// Update panics if prio is smaller than the priority of the last popped or peeked item
+func (q *Radix[P]) Update(item int, prio P) {
+	q.check(prio)
+	q.remove(item)
+	q.insert(item, prio)
+}
+
+func (q *Radix[P]) Peek() (int, P) {
+	q.fill()
+	item := q.buckets[0][len(q.buckets[0])-1]
+	return item, q.prio[item]
+}
+
+func (q *Radix[P]) Pop() (int, P) {
+	q.fill()
+	b := q.buckets[0]
+	item := b[len(b)-1]
+	q.buckets[0] = b[:len(b)-1]
+	q.bucket[item] = -1
+	q.size--
+	return item, q.prio[item]
+}
+
+// fill makes sure that the first bucket holds the items with the smallest key
+func (q *Radix[P]) fill() {
+	if len(q.buckets[0]) == 0 {
+		// find the first non empty bucket, its smallest key becomes the new last key
+		// and every item in it moves to a lower bucket
+		b := 1
+		for len(q.buckets[b]) == 0 {
+			b++
+		}
+		items := q.buckets[b]
+		q.last = q.key[items[0]]
+		for _, item := range items[1:] {
+			if q.key[item] < q.last {
+				q.last = q.key[item]
+			}
+		}
+		q.buckets[b] = items[:0]
+		for _, item := range items {
+			q.place(item)
+		}
+	}
+}
+
+func (q *Radix[P]) check(prio P) {
+	if q.keyOf(prio) < q.last {
+		panic("pq: radix heap priorities must not be smaller than the last popped or peeked priority")
+	}
+}
+
+func (q *Radix[P]) insert(item int, prio P) {
+	q.prio[item] = prio
+	q.key[item] = q.keyOf(prio)
+	q.place(item)
+}
+
+// place puts item into the bucket of the highest bit in which its key differs from the last key
+func (q *Radix[P]) place(item int) {
+	b := bits.Len64(q.key[item] ^ q.last)
+	q.bucket[item] = b
+	q.pos[item] = len(q.buckets[b])
+	q.buckets[b] = append(q.buckets[b], item)
+}
+
+func (q *Radix[P]) remove(item int) {
+	b := q.buckets[q.bucket[item]]
+	i, last := q.pos[item], len(b)-1
+	b[i] = b[last]
+	q.pos[b[i]] = i
+	q.buckets[q.bucket[item]] = b[:last]
+	q.bucket[item] = -1
+}
+
+// keyOf maps priorities to unsigned keys with the same order
+func (q *Radix[P]) keyOf(prio P) uint64 {
+	if q.signed {
+		return uint64(int64(prio)) ^ (1 << 63)
+	}
+	return uint64(prio)
+}
+
+// minSigned returns the smallest value of a signed integer type
+func minSigned[P Integer]() P {
+	var p P = 1
+	for p > 0 {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,30 @@
+package pq
+
+import "testing"
+
+func TestRadixSigned(t *testing.T) {
+	q := NewRadix[int8](3)
+	q.Push(0, 5)
+	q.Push(1, -128)
+	q.Push(2, -3)
+
+	for _, expect := range []int8{-128, -3, 5} {
+		if _, prio := q.Pop(); prio != expect {
+			t.Errorf("expected %d, got %d", expect, prio)
+		}
+	}
+}
+
+func TestRadixMonotone(t *testing.T) {
+	q := NewRadix[uint](2)
+	q.Push(0, 10)
+	q.Push(1, 20)
+	q.Pop()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for a priority below the last pop")
+		}
+	}()
+	q.Update(1, 5)
+}
@@ -0,0 +1,105 @@
+package graph
+
+// ShortestPathTreeOf is the result of a single source shortest path algorithm.
+// It stores the distance and the predecessor of every node on a shortest path from Source.
+type ShortestPathTreeOf[N comparable, W Number] struct {
+	Source N
+	nodes  denseView[N, W]
+	dist   []W
+	pre    []int // dense index of the predecessor, -1 for the source and unreachable nodes
+}
+
+type ShortestPathTree = ShortestPathTreeOf[int, float64]
+
+func newShortestPathTree[N comparable, W Number](d denseView[N, W], source int) *ShortestPathTreeOf[N, W] {
+	t := &ShortestPathTreeOf[N, W]{
+		Source: d.node(source),
+		nodes:  d,
+		dist:   make([]W, d.len()),
+		pre:    make([]int, d.len()),
+	}
+	inf := infinity[W]()
+	for i := range t.dist {
+		t.dist[i] = inf
+		t.pre[i] = -1
+	}
+	t.dist[source] = 0
+	return t
+}
+
+// Reachable reports whether there is a path from the source to v
+func (t *ShortestPathTreeOf[N, W]) Reachable(v N) bool {
+	i, ok := t.nodes.index(v)
+	return ok && t.dist[i] != infinity[W]()
+}
+
+// Unbounded reports whether v can be reached through a negative cycle,
+// so there are arbitrarily short paths from the source to v
+func (t *ShortestPathTreeOf[N, W]) Unbounded(v N) bool {
+	i, ok := t.nodes.index(v)
+	negInf := negativeInfinity[W]()
+	// unsigned weights have no -infinity
+	return ok && negInf < 0 && t.dist[i] == negInf
+}
+
+// DistTo returns the length of the shortest path from the source to v,
+// infinity if v is not reachable and -infinity if it is unbounded
+func (t *ShortestPathTreeOf[N, W]) DistTo(v N) W {
+	i, ok := t.nodes.index(v)
+	if !ok {
+		return infinity[W]()
+	}
+	return t.dist[i]
+}
+
+// PathTo returns the nodes on the shortest path from the source to v (both included)
+// and its length. It reports false if v is not reachable or unbounded.
+func (t *ShortestPathTreeOf[N, W]) PathTo(v N) ([]N, W, bool) {
+	i, ok := t.nodes.index(v)
+	if !ok || t.dist[i] == infinity[W]() {
+		return nil, infinity[W](), false
+	}
+	if t.Unbounded(v) {
+		return nil, t.dist[i], false
+	}
+	dense := t.pathTo(i)
+	path := make([]N, len(dense))
+	for j, u := range dense {
+		path[j] = t.nodes.node(u)
+	}
+	return path, t.dist[i], true
+}
+
+// pathTo returns the dense indices of the nodes on the path from the source to i
+func (t *ShortestPathTreeOf[N, W]) pathTo(i int) []int {
+	path := make([]int, 0)
+	for j := i; j != -1; j = t.pre[j] {
+		path = append(path, j)
+	}
+	// the path was collected from i back to the source
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+	return path
+}
+
+// Distances returns the distance of every node, unreachable nodes have distance infinity
+func (t *ShortestPathTreeOf[N, W]) Distances() map[N]W {
+	res := make(map[N]W, len(t.dist))
+	for i, d := range t.dist {
+		res[t.nodes.node(i)] = d
+	}
+	return res
+}
+
+// Predecessors returns the predecessor of every node on its shortest path.
+// The source and unreachable nodes have none.
+func (t *ShortestPathTreeOf[N, W]) Predecessors() map[N]N {
+	res := make(map[N]N, len(t.pre))
+	for i, p := range t.pre {
+		if p != -1 {
+			res[t.nodes.node(i)] = t.nodes.node(p)
+		}
+	}
+	return res
+}
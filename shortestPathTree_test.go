@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestShortestPathTreePathTo(t *testing.T) {
+	adjList := AdjList{
+		0: []WeightTuple{{1, 7}, {2, 12}},
+		1: []WeightTuple{{2, 2}, {3, 9}},
+		2: []WeightTuple{{4, 10}},
+		3: []WeightTuple{{5, 1}},
+		4: []WeightTuple{{3, 4}, {5, 5}},
+		5: []WeightTuple{},
+	}
+	g := FromAdjList(adjList)
+	g.AddNode(6)
+
+	tree, err := g.Dijkstra(0)
+	if err != nil {
+		t.Error(err)
+	}
+
+	path, cost, ok := tree.PathTo(5)
+	if !ok {
+		t.Errorf("expected 5 to be reachable")
+	}
+	expect := []int{0, 1, 3, 5}
+	if !reflect.DeepEqual(path, expect) || cost != 17 {
+		t.Errorf("expected %v with cost 17, got %v with cost %v", expect, path, cost)
+	}
+
+	path, _, ok = tree.PathTo(0)
+	if !ok || !reflect.DeepEqual(path, []int{0}) {
+		t.Errorf("expected the path to the source to be [0], got %v", path)
+	}
+
+	if tree.Reachable(6) {
+		t.Errorf("expected 6 to be unreachable")
+	}
+	if _, _, ok := tree.PathTo(6); ok {
+		t.Errorf("expected no path to 6")
+	}
+	if !math.IsInf(tree.DistTo(6), 1) || !math.IsInf(tree.DistTo(42), 1) {
+		t.Errorf("expected unreachable and unknown nodes to have infinite distance")
+	}
+}
+
+func TestShortestPathTreeBellmanFord(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(0, 2, 2)
+	g.AddEdge(2, 1, -1)
+	g.AddEdge(1, 3, 1)
+
+	tree, err := g.BellmanFord(0)
+	if err != nil {
+		t.Error(err)
+	}
+	path, cost, ok := tree.PathTo(3)
+	expect := []int{0, 2, 1, 3}
+	if !ok || !reflect.DeepEqual(path, expect) || cost != 2 {
+		t.Errorf("expected %v with cost 2, got %v with cost %v", expect, path, cost)
+	}
+}
@@ -0,0 +1,58 @@
+package graph
+
+import "errors"
+
+// SPFA (shortest path faster algorithm) is a queue based variant of Bellman-Ford.
+// Only the arcs of nodes whose distance changed are relaxed again, and the search ends as
+// soon as the queue runs empty, which is usually long before V-1 full passes.
+//
+// Negative cycles are detected by counting the relaxations on the path of every node: a path
+// with V arcs visits a node twice, so it runs through a cycle that made it shorter.
+// Results and errors are the same as those of BellmanFord.
+//
+// Time Complexity: O(V * E) in the worst case, often close to O(E)
+func SPFA[N comparable, W Number](g Reader[N, W], start N) (*ShortestPathTreeOf[N, W], error) {
+	d := viewOf(g)
+	s, ok := d.index(start)
+	if !ok {
+		return nil, errors.New("start node is not part of the graph")
+	}
+
+	numNodes := d.len()
+	tree := newShortestPathTree(d, s)
+	dist, pre := tree.dist, tree.pre
+	// count is the number of arcs on the current path of every node
+	count := make([]int, numNodes)
+	queued := make([]bool, numNodes)
+	queue := []int{s}
+	queued[s] = true
+
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		queued[u] = false
+		targets, weights := d.arcs(u)
+		for j, v := range targets {
+			if dist[v] <= dist[u]+weights[j] {
+				continue
+			}
+			dist[v] = dist[u] + weights[j]
+			pre[v] = u
+			count[v] = count[u] + 1
+			if count[v] >= numNodes {
+				// the distances are upper bounds, Bellman-Ford finishes them and finds the cycle
+				return tree, unbounded(d, tree, relaxArcs(d, dist, pre, numNodes-1))
+			}
+			if !queued[v] {
+				queued[v] = true
+				queue = append(queue, v)
+			}
+		}
+	}
+
+	return tree, nil
+}
+
+func (g *GraphOf[N, W]) SPFA(start N) (*ShortestPathTreeOf[N, W], error) {
+	return SPFA[N, W](g, start)
+}
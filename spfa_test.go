@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSPFA(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, -1)
+	g.AddEdge(0, 2, 4)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(1, 3, 2)
+	g.AddEdge(1, 4, 2)
+	g.AddEdge(3, 2, 5)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(4, 3, -3)
+
+	tree, err := g.SPFA(0)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := map[int]float64{0: 0, 1: -1, 2: 2, 3: -2, 4: 1}
+	if !reflect.DeepEqual(tree.Distances(), expect) {
+		t.Errorf("expected %v, got %v", expect, tree.Distances())
+	}
+
+	if _, err := g.SPFA(42); err == nil {
+		t.Errorf("expected an error for a start node that is not in the graph")
+	}
+}
+
+func TestSPFASparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(3, 100, 4)
+	g.AddEdge(100, 7000, -2)
+	g.AddEdge(3, 7000, 5)
+	g.AddNode(42)
+
+	tree, err := g.SPFA(3)
+	if err != nil {
+		t.Error(err)
+	}
+	expect := map[int]float64{3: 0, 100: 4, 7000: 2, 42: math.Inf(1)}
+	if !reflect.DeepEqual(tree.Distances(), expect) {
+		t.Errorf("expected %v, got %v", expect, tree.Distances())
+	}
+}
+
+func TestSPFAMatchesBellmanFord(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	p := make([]int, 100)
+	for i := range p {
+		p[i] = rnd.Intn(100)
+	}
+	g := NewGraphOf[int, int]()
+	for i := 0; i < 500; i++ {
+		u, v := rnd.Intn(100), rnd.Intn(100)
+		// negative weights, but no negative cycles
+		g.AddEdge(u, v, rnd.Intn(50)+p[u]-p[v])
+	}
+
+	for _, s := range []int{0, 10, 50} {
+		spfa, err := g.SPFA(s)
+		if err != nil {
+			t.Error(err)
+		}
+		bf, err := g.BellmanFord(s)
+		if err != nil {
+			t.Error(err)
+		}
+		if !reflect.DeepEqual(spfa.Distances(), bf.Distances()) {
+			t.Errorf("from %d expected the same distances as BellmanFord", s)
+		}
+	}
+}
+
+func TestSPFANegativeCycle(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, -2)
+	g.AddEdge(2, 1, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(0, 4, 5)
+	g.AddNode(5)
+
+	tree, err := g.SPFA(0)
+	var cycleErr *NegativeCycleError[int, float64]
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a NegativeCycleError, got %v", err)
+	}
+	if len(cycleErr.Cycle) != 2 || cycleErr.Weight != -1 {
+		t.Errorf("expected the cycle 1 -> 2 -> 1 with weight -1, got %v with weight %v", cycleErr.Cycle, cycleErr.Weight)
+	}
+
+	bf, _ := g.BellmanFord(0)
+	if !reflect.DeepEqual(tree.Distances(), bf.Distances()) {
+		t.Errorf("expected %v, got %v", bf.Distances(), tree.Distances())
+	}
+}
+
+func BenchmarkBellmanFord(b *testing.B) {
+	c := randomGraph(10000, 50000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.BellmanFord(0)
+	}
+}
+
+func BenchmarkSPFA(b *testing.B) {
+	c := randomGraph(10000, 50000, 1, false, 100).Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.SPFA(0)
+	}
+}
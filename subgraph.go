@@ -0,0 +1,49 @@
+package graph
+
+// Subgraph returns the subgraph induced by nodes, i.e. the given nodes and all edges
+// between them. Edge ids and the attributes of nodes and edges are preserved.
+func (g *GraphOf[N, W]) Subgraph(nodes []N) *GraphOf[N, W] {
+	sg := g.emptyCopy()
+	keep := make(map[N]bool, len(nodes))
+	for _, v := range nodes {
+		keep[v] = true
+	}
+
+	for _, v := range g.Nodes() {
+		if keep[v] {
+			sg.AddNode(v)
+		}
+	}
+	for _, e := range g.idEdges() {
+		if keep[e.From] && keep[e.To] {
+			sg.addEdgeWithID(e.From, e.To, e.Weight, e.ID)
+		}
+	}
+	sg.copyAttrs(g)
+	return sg
+}
+
+// emptyCopy returns a graph without nodes of the same kind as g
+func (g *GraphOf[N, W]) emptyCopy() *GraphOf[N, W] {
+	res := NewGraphOf[N, W]()
+	res.undirected = g.undirected
+	res.multi = g.multi
+	res.nextID = g.nextID
+	return res
+}
+
+// copyAttrs copies the attributes of all nodes and edges of g that also exist in the graph
+func (g *GraphOf[N, W]) copyAttrs(from *GraphOf[N, W]) {
+	for v, attrs := range from.nodeAttrs {
+		if g.HasNode(v) {
+			for key, value := range attrs {
+				g.SetNodeAttr(v, key, value)
+			}
+		}
+	}
+	for id, attrs := range from.edgeAttrs {
+		for key, value := range attrs {
+			g.SetEdgeAttr(id, key, value)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package graph
+
+import "sync"
+
+// SyncGraphOf is a graph that is safe for concurrent use. Writes are serialized,
+// readers run algorithms on an immutable snapshot, so they always see a consistent graph
+// while writers keep changing it.
+//
+//	sg.AddEdge(0, 1, 1)                 // from any goroutine
+//	tree, err := sg.Snapshot().Dijkstra(0)
+type SyncGraphOf[N comparable, W Number] struct {
+	mu   sync.RWMutex
+	g    *GraphOf[N, W]
+	snap *CSROf[N, W] // cached snapshot, reset by every write
+}
+
+type SyncGraph = SyncGraphOf[int, float64]
+
+func NewSyncGraph() *SyncGraph {
+	return NewSyncGraphOf[int, float64]()
+}
+
+func NewSyncGraphOf[N comparable, W Number]() *SyncGraphOf[N, W] {
+	return Synchronized(NewGraphOf[N, W]())
+}
+
+// Synchronized wraps g in a SyncGraphOf. g must not be used directly afterwards.
+func Synchronized[N comparable, W Number](g *GraphOf[N, W]) *SyncGraphOf[N, W] {
+	return &SyncGraphOf[N, W]{g: g}
+}
+
+// Snapshot returns an immutable snapshot of the current graph. Snapshots are cached
+// until the next write, so taking one without changes in between is cheap.
+func (s *SyncGraphOf[N, W]) Snapshot() *CSROf[N, W] {
+	s.mu.RLock()
+	snap := s.snap
+	s.mu.RUnlock()
+	if snap != nil {
+		return snap
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snap == nil {
+		s.snap = s.g.Freeze()
+	}
+	return s.snap
+}
+
+// Clone returns a mutable copy of the current graph
+func (s *SyncGraphOf[N, W]) Clone() *GraphOf[N, W] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Clone()
+}
+
+// write runs fn with exclusive access to the graph and invalidates the snapshot
+func (s *SyncGraphOf[N, W]) write(fn func(g *GraphOf[N, W])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.g)
+	s.snap = nil
+}
+
+func (s *SyncGraphOf[N, W]) AddNode(val N) {
+	s.write(func(g *GraphOf[N, W]) { g.AddNode(val) })
+}
+
+func (s *SyncGraphOf[N, W]) AddEdge(from, to N, weight W) EdgeID {
+	var id EdgeID
+	s.write(func(g *GraphOf[N, W]) { id = g.AddEdge(from, to, weight) })
+	return id
+}
+
+func (s *SyncGraphOf[N, W]) UpdateEdge(from, to N, weight W) {
+	s.write(func(g *GraphOf[N, W]) { g.UpdateEdge(from, to, weight) })
+}
+
+func (s *SyncGraphOf[N, W]) UpdateEdgeByID(id EdgeID, weight W) {
+	s.write(func(g *GraphOf[N, W]) { g.UpdateEdgeByID(id, weight) })
+}
+
+func (s *SyncGraphOf[N, W]) RemoveEdge(from, to N) {
+	s.write(func(g *GraphOf[N, W]) { g.RemoveEdge(from, to) })
+}
+
+func (s *SyncGraphOf[N, W]) RemoveEdgeByID(id EdgeID) {
+	s.write(func(g *GraphOf[N, W]) { g.RemoveEdgeByID(id) })
+}
+
+func (s *SyncGraphOf[N, W]) RemoveNode(val N) {
+	s.write(func(g *GraphOf[N, W]) { g.RemoveNode(val) })
+}
+
+func (s *SyncGraphOf[N, W]) SetNodeAttr(v N, key string, value any) {
+	s.write(func(g *GraphOf[N, W]) { g.SetNodeAttr(v, key, value) })
+}
+
+func (s *SyncGraphOf[N, W]) SetEdgeAttr(id EdgeID, key string, value any) bool {
+	var ok bool
+	s.write(func(g *GraphOf[N, W]) { ok = g.SetEdgeAttr(id, key, value) })
+	return ok
+}
+
+func (s *SyncGraphOf[N, W]) HasNode(val N) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.HasNode(val)
+}
+
+func (s *SyncGraphOf[N, W]) NumNodes() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.NumNodes()
+}
+
+func (s *SyncGraphOf[N, W]) NumEdges() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.NumEdges()
+}
@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncGraphSnapshot(t *testing.T) {
+	sg := NewSyncGraph()
+	sg.AddEdge(0, 1, 1)
+	sg.AddEdge(1, 2, 1)
+
+	snap := sg.Snapshot()
+	if sg.Snapshot() != snap {
+		t.Errorf("expected the snapshot to be cached")
+	}
+
+	sg.AddEdge(2, 3, 1)
+	if snap.NumNodes() != 3 {
+		t.Errorf("expected the old snapshot to keep 3 nodes, got %d", snap.NumNodes())
+	}
+	if sg.Snapshot().NumNodes() != 4 || sg.NumNodes() != 4 {
+		t.Errorf("expected a new snapshot with 4 nodes")
+	}
+}
+
+// run with go test -race
+func TestSyncGraphConcurrent(t *testing.T) {
+	sg := NewSyncGraph()
+	sg.AddNode(-1)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				sg.AddEdge(w*100+i, w*100+i+1, 1)
+				sg.AddEdge(-1, w*100+i, float64(i))
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				snap := sg.Snapshot()
+				tree, err := snap.Dijkstra(-1)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				dist := tree.Distances()
+				if len(dist) != snap.NumNodes() {
+					t.Errorf("expected a distance for each of the %d nodes, got %d", snap.NumNodes(), len(dist))
+				}
+				snap.BFS(-1, func(int) {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sg.NumEdges() != 800 {
+		t.Errorf("expected 800 edges, got %d", sg.NumEdges())
+	}
+}
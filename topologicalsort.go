@@ -2,16 +2,38 @@ package graph
 
 import "errors"
 
-func (g *Graph) TopologicalSort() ([]int, error) {
-	if g.HasCycle() {
-		return nil, errors.New("graph has cycle")
+func TopologicalSort[N comparable, W Number](g Reader[N, W]) ([]N, error) {
+	d, dense, err := dagOrder(g)
+	if err != nil {
+		return nil, err
 	}
-	visited := make([]bool, g.NumNodes())
-	stack := make([]int, 0)
+	order := make([]N, 0, d.len())
+	for _, v := range dense {
+		order = append(order, d.node(v))
+	}
+	return order, nil
+}
+
+// dagOrder checks that g is a directed acyclic graph and returns its dense view in topological order
+func dagOrder[N comparable, W Number](g Reader[N, W]) (denseView[N, W], []int, error) {
+	if !g.IsDirected() {
+		return nil, nil, errors.New("topological sort requires a directed graph")
+	}
+	if HasCycle(g) {
+		return nil, nil, errors.New("graph has cycle")
+	}
+	d := viewOf(g)
+	return d, topologicalOrder(d), nil
+}
+
+// topologicalOrder returns the dense indices of an acyclic view in topological order
+func topologicalOrder[N comparable, W Number](d denseView[N, W]) []int {
+	visited := make([]bool, d.len())
+	stack := make([]int, 0, d.len())
 
-	for i := 0; i < g.NumNodes(); i++ {
+	for i := range visited {
 		if !visited[i] {
-			g.TopologicalStep(i, visited, &stack)
+			topologicalStep(d, i, visited, &stack)
 		}
 	}
 
@@ -20,14 +42,32 @@ func (g *Graph) TopologicalSort() ([]int, error) {
 		stack[i], stack[j] = stack[j], stack[i]
 	}
 
-	return stack, nil
+	return stack
+}
+
+func (g *GraphOf[N, W]) TopologicalSort() ([]N, error) {
+	return TopologicalSort[N, W](g)
+}
+
+// TopologicalStep pushes node and all nodes reachable from it onto stack in post-order.
+// visited is indexed by the position of a node in g.Nodes()
+func (g *GraphOf[N, W]) TopologicalStep(node N, visited []bool, stack *[]N) {
+	d := viewOf[N, W](g)
+	if n, ok := d.index(node); ok {
+		dense := make([]int, 0)
+		topologicalStep(d, n, visited, &dense)
+		for _, v := range dense {
+			*stack = append(*stack, d.node(v))
+		}
+	}
 }
 
-func (g *Graph) TopologicalStep(node int, visited []bool, stack *[]int) {
+func topologicalStep[N comparable, W Number](d denseView[N, W], node int, visited []bool, stack *[]int) {
 	visited[node] = true
-	for _, e := range g.AdjEdges(node) {
-		if !visited[e.To] {
-			g.TopologicalStep(e.To, visited, stack)
+	targets, _ := d.arcs(node)
+	for _, to := range targets {
+		if !visited[to] {
+			topologicalStep(d, to, visited, stack)
 		}
 	}
 	*stack = append(*stack, node)
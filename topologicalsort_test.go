@@ -83,3 +83,29 @@ func TestTopologicalSort2(t *testing.T) {
 		t.Errorf("TopologicalSort() = %v, want %v", ts, expect)
 	}
 }
+
+func TestTopologicalSortSparse(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(7000, 3, 1)
+	g.AddEdge(3, 100, 1)
+
+	ts, err := g.TopologicalSort()
+	if err != nil {
+		t.Errorf("TopologicalSort() error: %v", err)
+	}
+
+	expect := []int{7000, 3, 100}
+	if !reflect.DeepEqual(ts, expect) {
+		t.Errorf("TopologicalSort() = %v, want %v", ts, expect)
+	}
+}
+
+func TestTopologicalSortUndirected(t *testing.T) {
+	g := NewUndirectedGraph()
+	g.AddEdge(0, 1, 1)
+
+	_, err := g.TopologicalSort()
+	if err == nil {
+		t.Errorf("expected an error for an undirected graph")
+	}
+}
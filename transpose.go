@@ -1,10 +1,20 @@
 package graph
 
-func (g *Graph) Transpose() *Graph {
-	tg := NewGraph()
-	for _, edge := range g.Edges() {
-		tg.AddEdge(edge.To, edge.From, edge.Weight)
+// Transpose returns the graph with all edges reversed.
+// Edge ids and the attributes of nodes and edges are preserved.
+// The transpose of an undirected graph is a copy of the graph itself.
+func (g *GraphOf[N, W]) Transpose() *GraphOf[N, W] {
+	if g.undirected {
+		return g.Clone()
 	}
+	tg := g.emptyCopy()
+	for _, v := range g.Nodes() {
+		tg.AddNode(v)
+	}
+	for _, edge := range g.idEdges() {
+		tg.addEdgeWithID(edge.To, edge.From, edge.Weight, edge.ID)
+	}
+	tg.copyAttrs(g)
 
 	return tg
 }
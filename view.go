@@ -0,0 +1,108 @@
+package graph
+
+// Reader is a read-only view of a graph. All algorithms are functions over this interface,
+// so they run on anything that implements it: a *GraphOf, a frozen *CSROf, or a custom
+// storage like a database backed view or an implicit grid, without copying it first.
+type Reader[N comparable, W Number] interface {
+	// Nodes returns all nodes in a deterministic order
+	Nodes() []N
+	// Neighbors returns the arcs leaving v. For undirected graphs these are all edges incident to v.
+	Neighbors(v N) []WeightTupleOf[N, W]
+	// Weight returns the weight of the arc from u to v, or false if there is none
+	Weight(u, v N) (W, bool)
+	HasEdge(u, v N) bool
+	IsDirected() bool
+}
+
+// denseView numbers the nodes of a graph 0..n-1 in the order of Nodes(),
+// so the algorithms can use slices instead of maps.
+type denseView[N comparable, W Number] interface {
+	len() int
+	node(i int) N
+	index(v N) (int, bool)
+	// arcs returns the dense indices and weights of the arcs leaving node i
+	arcs(i int) ([]int, []W)
+}
+
+// viewOf returns a dense view of g. Readers that are already dense (like CSR) are used
+// as they are, all others are wrapped without copying their edges.
+func viewOf[N comparable, W Number](g Reader[N, W]) denseView[N, W] {
+	if d, ok := g.(denseView[N, W]); ok {
+		return d
+	}
+	ids := g.Nodes()
+	pos := make(map[N]int, len(ids))
+	for i, v := range ids {
+		pos[v] = i
+	}
+	return &readerView[N, W]{g, &nodeIndex[N]{ids, pos}}
+}
+
+type readerView[N comparable, W Number] struct {
+	g   Reader[N, W]
+	idx *nodeIndex[N]
+}
+
+func (r *readerView[N, W]) len() int {
+	return r.idx.len()
+}
+
+func (r *readerView[N, W]) node(i int) N {
+	return r.idx.ids[i]
+}
+
+func (r *readerView[N, W]) index(v N) (int, bool) {
+	i, ok := r.idx.pos[v]
+	return i, ok
+}
+
+func (r *readerView[N, W]) arcs(i int) ([]int, []W) {
+	adj := r.g.Neighbors(r.idx.ids[i])
+	targets := make([]int, len(adj))
+	weights := make([]W, len(adj))
+	for j, e := range adj {
+		targets[j] = r.idx.pos[e.To]
+		weights[j] = e.Weight
+	}
+	return targets, weights
+}
+
+// hasNegativeArcs reports whether any arc of d has a negative weight
+func hasNegativeArcs[N comparable, W Number](d denseView[N, W]) bool {
+	for i := 0; i < d.len(); i++ {
+		_, weights := d.arcs(i)
+		for _, w := range weights {
+			if w < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// transposeView is a dense view with all arcs of d reversed
+type transposeView[N comparable, W Number] struct {
+	denseView[N, W]
+	targets [][]int
+	weights [][]W
+}
+
+func transposeOf[N comparable, W Number](d denseView[N, W]) *transposeView[N, W] {
+	t := &transposeView[N, W]{
+		denseView: d,
+		targets:   make([][]int, d.len()),
+		weights:   make([][]W, d.len()),
+	}
+	for i := 0; i < d.len(); i++ {
+		targets, weights := d.arcs(i)
+		for j, to := range targets {
+			t.targets[to] = append(t.targets[to], i)
+			t.weights[to] = append(t.weights[to], weights[j])
+		}
+	}
+	return t
+}
+
+func (t *transposeView[N, W]) arcs(i int) ([]int, []W) {
+	return t.targets[i], t.weights[i]
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+type cell struct{ row, col int }
+
+// grid is an implicit, undirected grid graph that never materializes its edges
+type grid struct{ rows, cols int }
+
+func (g grid) Nodes() []cell {
+	nodes := make([]cell, 0, g.rows*g.cols)
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			nodes = append(nodes, cell{r, c})
+		}
+	}
+	return nodes
+}
+
+func (g grid) Neighbors(v cell) []WeightTupleOf[cell, int] {
+	adj := make([]WeightTupleOf[cell, int], 0, 4)
+	for _, d := range []cell{{-1, 0}, {0, -1}, {0, 1}, {1, 0}} {
+		n := cell{v.row + d.row, v.col + d.col}
+		if n.row >= 0 && n.row < g.rows && n.col >= 0 && n.col < g.cols {
+			adj = append(adj, WeightTupleOf[cell, int]{n, 1})
+		}
+	}
+	return adj
+}
+
+func (g grid) Weight(u, v cell) (int, bool) {
+	if g.HasEdge(u, v) {
+		return 1, true
+	}
+	return 0, false
+}
+
+func (g grid) HasEdge(u, v cell) bool {
+	for _, e := range g.Neighbors(u) {
+		if e.To == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (g grid) IsDirected() bool {
+	return false
+}
+
+func TestAlgorithmsOnCustomReader(t *testing.T) {
+	g := grid{3, 4}
+
+	tree, err := Dijkstra[cell, int](g, cell{0, 0})
+	if err != nil {
+		t.Error(err)
+	}
+	dist, pre := tree.Distances(), tree.Predecessors()
+	if dist[cell{2, 3}] != 5 {
+		t.Errorf("expected distance 5 to the opposite corner, got %d", dist[cell{2, 3}])
+	}
+	if _, ok := pre[cell{0, 0}]; ok {
+		t.Errorf("expected the start node to have no predecessor")
+	}
+
+	visited := 0
+	BFS[cell, int](g, cell{1, 1}, func(cell) { visited++ })
+	if visited != 12 {
+		t.Errorf("expected to visit 12 cells, got %d", visited)
+	}
+
+	if scc := Kosaraju[cell, int](g); len(scc) != 1 {
+		t.Errorf("expected a single component, got %v", scc)
+	}
+	if !HasCycle[cell, int](g) {
+		t.Errorf("expected the grid to have cycles")
+	}
+
+	mst, err := Prim[cell, int](g)
+	if err != nil {
+		t.Error(err)
+	}
+	if mst.NumNodes() != 12 || mst.NumEdges() != 11 {
+		t.Errorf("expected a spanning tree with 12 nodes and 11 edges, got %d and %d", mst.NumNodes(), mst.NumEdges())
+	}
+}
+
+func TestWeightAndHasEdge(t *testing.T) {
+	g := NewMultiGraph()
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 1)
+
+	for _, r := range []Reader[int, float64]{g, g.Freeze()} {
+		if w, ok := r.Weight(0, 1); !ok || w != 2 {
+			t.Errorf("expected weight 2 of the lightest edge, got %v", w)
+		}
+		if _, ok := r.Weight(1, 0); ok {
+			t.Errorf("expected no edge from 1 to 0")
+		}
+		if !r.HasEdge(1, 2) || r.HasEdge(2, 1) || r.HasEdge(5, 1) {
+			t.Errorf("expected only the edge 1 ---> 2")
+		}
+	}
+}
+
+func TestCSRMatchesGraph(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(1, 0, 1)
+	g.AddEdge(0, 3, 1)
+	g.AddEdge(3, 2, 1)
+	g.AddEdge(2, 1, 1)
+	g.AddEdge(4, 2, 1)
+	g.AddEdge(4, 6, 1)
+	g.AddEdge(5, 4, 1)
+	g.AddEdge(6, 5, 1)
+	c := g.Freeze()
+
+	if !reflect.DeepEqual(g.Kosaraju(), c.Kosaraju()) {
+		t.Errorf("expected the same components, got %v and %v", g.Kosaraju(), c.Kosaraju())
+	}
+	if !reflect.DeepEqual(g.FloydWarshall().Matrix(), c.FloydWarshall().Matrix()) {
+		t.Errorf("expected the same distances")
+	}
+	if !reflect.DeepEqual(g.Laplacian(), c.Laplacian()) {
+		t.Errorf("expected the same laplacian")
+	}
+	if g.HasCycle() != c.HasCycle() {
+		t.Errorf("expected the same cycle detection result")
+	}
+}